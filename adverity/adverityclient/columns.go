@@ -32,3 +32,18 @@ func (client *Client) CreateColumns(datastreamID string, columns []ColumnConfig)
 	}
 	return *resultColumns, nil
 }
+
+func (c ColumnConfig) MarshalJSON() ([]byte, error) {
+	m := map[string]interface{}{
+		"name":          c.Name,
+		"datatype":      c.Type,
+		"is_key_column": c.IsKeyColumn,
+		"removed":       c.Removed,
+	}
+	if c.TargetColumn != nil {
+		m["target_column"] = c.TargetColumn
+	} else if c.ClearTargetColumn {
+		m["target_column"] = nil
+	}
+	return json.Marshal(m)
+}
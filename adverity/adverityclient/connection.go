@@ -105,3 +105,61 @@ func (client *Client) DeleteConnection(id string, connection_type_id int) (*http
 	return response, nil
 
 }
+
+func (client *Client) ListConnections(filters []Query) ([]Connection, error) {
+	u := *client.restURL
+	u.Path = u.Path + "connections/"
+	page := 1
+	queries := append([]Query{
+		{
+			Key:   "page",
+			Value: strconv.Itoa(page),
+		},
+	}, filters...)
+
+	connections := []Connection{}
+	for {
+		response, err := client.sendRequestQuery(u, queries)
+		if err != nil {
+			return nil, err
+		}
+		resultsMap := &ConnectionResults{}
+		if !responseOK(response) {
+			defer response.Body.Close()
+			body, _ := ioutil.ReadAll(response.Body)
+			return nil, errorString{"Failed listing connections. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
+		}
+		err = getJSON(response, resultsMap)
+		if err != nil {
+			return nil, err
+		}
+		connections = append(connections, resultsMap.Results...)
+		if resultsMap.Next == "" {
+			break
+		} else {
+			page = page + 1
+			queries[0] = Query{
+				Key:   "page",
+				Value: strconv.Itoa(page),
+			}
+		}
+	}
+	return connections, nil
+}
+
+// DeleteConnectionByID deletes a connection without knowing its connection type.
+func (client *Client) DeleteConnectionByID(id string) (*http.Response, error) {
+	u := *client.restURL
+	u.Path = u.Path + "connections/" + id + "/"
+	response, err := client.sendRequestDelete(u)
+	if err != nil {
+		return nil, err
+	}
+	if !responseOK(response) {
+		defer response.Body.Close()
+		body, _ := ioutil.ReadAll(response.Body)
+		return response, errorString{"Failed deleting connection. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
+	}
+
+	return response, nil
+}
@@ -7,34 +7,67 @@ import (
 	"strings"
 )
 
-func (client *Client) LookupConnectionApp(connectionTypeID int, selector string) (int, error) {
+func (client *Client) ReadConnectionOptions(connectionTypeID int) (*ConnectionOptions, error) {
 	u := *client.restURL
 	u.Path = u.Path + "connection-types/" + strconv.Itoa(connectionTypeID) + "/connections/"
 	response, err := client.sendRequestOptions(u)
 	if err != nil {
-		return -1, err
+		return nil, err
 	}
 	if !responseOK(response) {
 		defer response.Body.Close()
 		body, _ := ioutil.ReadAll(response.Body)
-		return -1, errorString{"Failed querying connection apps. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
+		return nil, errorString{"Failed querying connection apps. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
 	}
 	resMap := &ConnectionOptions{}
 	err = getJSON(response, resMap)
+	if err != nil {
+		return nil, err
+	}
+	return resMap, nil
+}
+
+// IntValue returns the value of a choice as an integer, as is the case for apps.
+func (c FieldChoice) IntValue() (int, error) {
+	switch value := c.Value.(type) {
+	case float64:
+		if value == float64(int(value)) {
+			return int(value), nil
+		}
+	case string:
+		return strconv.Atoi(value)
+	}
+	return -1, errorString{fmt.Sprintf("Choice %s has value %v, which is not a number", c.DisplayName, c.StringValue())}
+}
+
+// StringValue returns the value of a choice as a string, without formatting numbers in exponent notation.
+func (c FieldChoice) StringValue() string {
+	switch value := c.Value.(type) {
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case string:
+		return value
+	}
+	return fmt.Sprint(c.Value)
+}
+
+func (client *Client) LookupConnectionApp(connectionTypeID int, selector string) (int, error) {
+	resMap, err := client.ReadConnectionOptions(connectionTypeID)
 	if err != nil {
 		return -1, err
 	}
-	if len(resMap.Actions["POST"].App.Choices) > 1 {
+	choices := resMap.Actions["POST"]["app"].Choices
+	if len(choices) > 1 {
 		stringList := []string{}
-		for _, choice := range resMap.Actions["POST"].App.Choices {
+		for _, choice := range choices {
 			if choice.DisplayName == selector {
-				return choice.Value, nil
+				return choice.IntValue()
 			}
 			stringList = append(stringList, choice.DisplayName)
 		}
 		return -1, errorString{fmt.Sprintf("Multiple app options found for connection type, none matched the selector (or no selector was given): %s", strings.Join(stringList, ", "))}
-	} else if len(resMap.Actions["POST"].App.Choices) < 1 {
+	} else if len(choices) < 1 {
 		return -1, errorString{"No app options found for connection type."}
 	}
-	return resMap.Actions["POST"].App.Choices[0].Value, nil
+	return choices[0].IntValue()
 }
@@ -30,3 +30,52 @@ func (client *Client) LookupConnectionTypes(searchTerm string) ([]ConnectionType
 	}
 	return resMap.Results, nil
 }
+
+// ListConnectionTypes lists all connection types matching the search term, going through all pages. An empty search term
+// lists every connection type.
+func (client *Client) ListConnectionTypes(searchTerm string) ([]ConnectionType, error) {
+	u := *client.restURL
+	u.Path = u.Path + "connection-types/"
+	page := 1
+	queries := []Query{
+		{
+			Key:   "page",
+			Value: strconv.Itoa(page),
+		},
+	}
+	if searchTerm != "" {
+		queries = append(queries, Query{
+			Key:   "search",
+			Value: searchTerm,
+		})
+	}
+
+	connectionTypes := []ConnectionType{}
+	for {
+		response, err := client.sendRequestQuery(u, queries)
+		if err != nil {
+			return nil, err
+		}
+		resultsMap := &ConnectionTypeResults{}
+		if !responseOK(response) {
+			defer response.Body.Close()
+			body, _ := ioutil.ReadAll(response.Body)
+			return nil, errorString{"Failed listing connection types. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
+		}
+		err = getJSON(response, resultsMap)
+		if err != nil {
+			return nil, err
+		}
+		connectionTypes = append(connectionTypes, resultsMap.Results...)
+		if resultsMap.Next == "" {
+			break
+		} else {
+			page = page + 1
+			queries[0] = Query{
+				Key:   "page",
+				Value: strconv.Itoa(page),
+			}
+		}
+	}
+	return connectionTypes, nil
+}
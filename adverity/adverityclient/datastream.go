@@ -34,6 +34,30 @@ func (client *Client) ReadDatastream(id string, datastream_type_id int) (*Datast
 
 }
 
+func (client *Client) ReadDatastreamByID(id string) (*Datastream, error, int) {
+	u := *client.restURL
+	u.Path = u.Path + "datastreams/" + id + "/"
+	response, err := client.sendRequestRead(u)
+	if err != nil {
+		return nil, err, 0
+	}
+
+	resMap := &Datastream{}
+	if !responseOK(response) {
+		defer response.Body.Close()
+		body, _ := ioutil.ReadAll(response.Body)
+		return resMap, errorString{"Failed reading datastream. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}, response.StatusCode
+	}
+
+	err = getJSON(response, resMap)
+	if err != nil {
+		return nil, err, response.StatusCode
+	}
+
+	return resMap, nil, response.StatusCode
+
+}
+
 func (client *Client) DatastreamExists(id string) (bool, error) {
 	u := *client.restURL
 	u.Path = u.Path + "datastreams/" + id + "/"
@@ -267,3 +291,44 @@ func (client *Client) DataStreamChangeDatatype(conf DatastreamDatatypeConfig, id
 
 	return response, nil
 }
+
+func (client *Client) ListDatastreams(filters []Query) ([]Datastream, error) {
+	u := *client.restURL
+	u.Path = u.Path + "datastreams/"
+	page := 1
+	queries := append([]Query{
+		{
+			Key:   "page",
+			Value: strconv.Itoa(page),
+		},
+	}, filters...)
+
+	datastreams := []Datastream{}
+	for {
+		response, err := client.sendRequestQuery(u, queries)
+		if err != nil {
+			return nil, err
+		}
+		resultsMap := &DatastreamResults{}
+		if !responseOK(response) {
+			defer response.Body.Close()
+			body, _ := ioutil.ReadAll(response.Body)
+			return nil, errorString{"Failed listing datastreams. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
+		}
+		err = getJSON(response, resultsMap)
+		if err != nil {
+			return nil, err
+		}
+		datastreams = append(datastreams, resultsMap.Results...)
+		if resultsMap.Next == "" {
+			break
+		} else {
+			page = page + 1
+			queries[0] = Query{
+				Key:   "page",
+				Value: strconv.Itoa(page),
+			}
+		}
+	}
+	return datastreams, nil
+}
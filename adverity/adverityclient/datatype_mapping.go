@@ -68,3 +68,20 @@ func (client *Client) PatchColumn(columnID string, dataType string) error {
 	}
 	return nil
 }
+
+func (client *Client) PatchColumnKey(columnID string, isKeyColumn bool) error {
+	u := *client.restURL
+	u.Path = u.Path + "columns/" + columnID + "/"
+	keyMap := map[string]bool{"is_key_column": isKeyColumn}
+	body, _ := json.Marshal(keyMap)
+	response, err := client.sendRequestUpdate(u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if !responseOK(response) {
+		defer response.Body.Close()
+		body, _ := ioutil.ReadAll(response.Body)
+		return errorString{"Failed patching key of column. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
+	}
+	return nil
+}
@@ -95,3 +95,63 @@ func (client *Client) DeleteDestination(id string, destination_type_id int) (*ht
 	return response, nil
 
 }
+
+func (client *Client) ListDestinations(destination_type_id int) ([]Destination, error) {
+	u := *client.restURL
+	u.Path = u.Path + "target-types/" + strconv.Itoa(destination_type_id) + "/targets/"
+	page := 1
+	queries := []Query{
+		{
+			Key:   "page",
+			Value: strconv.Itoa(page),
+		},
+	}
+
+	destinations := []Destination{}
+	for {
+		response, err := client.sendRequestQuery(u, queries)
+		if err != nil {
+			return nil, err
+		}
+		resultsMap := &DestinationResults{}
+		if !responseOK(response) {
+			defer response.Body.Close()
+			body, _ := ioutil.ReadAll(response.Body)
+			return nil, errorString{"Failed listing Destinations. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
+		}
+		err = getJSON(response, resultsMap)
+		if err != nil {
+			return nil, err
+		}
+		destinations = append(destinations, resultsMap.Results...)
+		if resultsMap.Next == "" {
+			break
+		} else {
+			page = page + 1
+			queries[0] = Query{
+				Key:   "page",
+				Value: strconv.Itoa(page),
+			}
+		}
+	}
+	return destinations, nil
+}
+
+// FindDestinationType returns the ID of the type of a destination. Destinations can only be read through their type, so
+// the destination is read once for every destination type until it is found.
+func (client *Client) FindDestinationType(destination_id int) (int, error) {
+	destinationTypes, err := client.LookupDestinationTypes("")
+	if err != nil {
+		return 0, err
+	}
+	for _, destinationType := range destinationTypes {
+		_, err, code := client.ReadDestination(strconv.Itoa(destination_id), destinationType.ID)
+		if err == nil {
+			return destinationType.ID, nil
+		}
+		if code != 404 {
+			return 0, err
+		}
+	}
+	return 0, errorString{"Could not find the type of destination " + strconv.Itoa(destination_id)}
+}
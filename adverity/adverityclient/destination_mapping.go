@@ -56,7 +56,7 @@ func (client *Client) CreateDestinationMapping(conf DestinationMappingConfig, de
 	return resMap, nil
 }
 
-func (client *Client) UpdateDestinationMapping(conf DestinationMappingConfig, destination_type int, destination_id int, id int) (*DestinationMapping, error) {
+func (client *Client) UpdateDestinationMapping(conf DestinationMappingUpdateConfig, destination_type int, destination_id int, id int) (*DestinationMapping, error) {
 	u := *client.restURL
 	u.Path = u.Path + "target-types/" + strconv.Itoa(destination_type) + "/targets/" + strconv.Itoa(destination_id) + "/mappings/" + strconv.Itoa(id) + "/"
 
@@ -96,3 +96,82 @@ func (client *Client) DeleteDestinationMapping(id int, destination_type int, des
 
 	return response, nil
 }
+
+func (client *Client) ListDestinationMappings(destination_type int, destination_id int, filters []Query) ([]DestinationMapping, error) {
+	u := *client.restURL
+	u.Path = u.Path + "target-types/" + strconv.Itoa(destination_type) + "/targets/" + strconv.Itoa(destination_id) + "/mappings/"
+	page := 1
+	queries := append([]Query{
+		{
+			Key:   "page",
+			Value: strconv.Itoa(page),
+		},
+	}, filters...)
+
+	mappings := []DestinationMapping{}
+	for {
+		response, err := client.sendRequestQuery(u, queries)
+		if err != nil {
+			return nil, err
+		}
+		resultsMap := &DestinationMappingResults{}
+		if !responseOK(response) {
+			defer response.Body.Close()
+			body, _ := ioutil.ReadAll(response.Body)
+			return nil, errorString{"Failed listing Destination Mappings. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
+		}
+		err = getJSON(response, resultsMap)
+		if err != nil {
+			return nil, err
+		}
+		for _, mapping := range resultsMap.Results {
+			mapping.DestinationType = destination_type
+			mappings = append(mappings, mapping)
+		}
+		if resultsMap.Next == "" {
+			break
+		} else {
+			page = page + 1
+			queries[0] = Query{
+				Key:   "page",
+				Value: strconv.Itoa(page),
+			}
+		}
+	}
+	return mappings, nil
+}
+
+// ListDatastreamDestinationMappings lists the mappings of a datastream to all destinations it writes to. The API has no
+// endpoint for this, so the mappings of every destination of every destination type are searched.
+func (client *Client) ListDatastreamDestinationMappings(datastream_id int) ([]DestinationMapping, error) {
+	destinationTypes, err := client.LookupDestinationTypes("")
+	if err != nil {
+		return nil, err
+	}
+	filters := []Query{
+		{
+			Key:   "datastream",
+			Value: strconv.Itoa(datastream_id),
+		},
+	}
+	mappings := []DestinationMapping{}
+	for _, destinationType := range destinationTypes {
+		destinations, err := client.ListDestinations(destinationType.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, destination := range destinations {
+			destinationMappings, err := client.ListDestinationMappings(destinationType.ID, destination.ID, filters)
+			if err != nil {
+				return nil, err
+			}
+			for _, mapping := range destinationMappings {
+				// Don't rely on the filter being supported, only keep the mappings of the datastream
+				if mapping.Datastream == datastream_id {
+					mappings = append(mappings, mapping)
+				}
+			}
+		}
+	}
+	return mappings, nil
+}
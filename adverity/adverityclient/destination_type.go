@@ -8,25 +8,67 @@ import (
 func (client *Client) LookupDestinationTypes(searchTerm string) ([]DestinationType, error) {
 	u := *client.restURL
 	u.Path = u.Path + "target-types/"
+	page := 1
 	queries := []Query{
+		{
+			Key:   "page",
+			Value: strconv.Itoa(page),
+		},
 		{
 			Key:   "search",
 			Value: searchTerm,
 		},
 	}
-	response, err := client.sendRequestQuery(u, queries)
+
+	destinationTypes := []DestinationType{}
+	for {
+		response, err := client.sendRequestQuery(u, queries)
+		if err != nil {
+			return nil, err
+		}
+		if !responseOK(response) {
+			defer response.Body.Close()
+			body, _ := ioutil.ReadAll(response.Body)
+			return nil, errorString{"Failed querying destination types. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
+		}
+		resMap := &DestinationTypeResults{}
+		err = getJSON(response, resMap)
+		if err != nil {
+			return nil, err
+		}
+		destinationTypes = append(destinationTypes, resMap.Results...)
+		if resMap.Next == "" {
+			break
+		} else {
+			page = page + 1
+			queries[0] = Query{
+				Key:   "page",
+				Value: strconv.Itoa(page),
+			}
+		}
+	}
+	return destinationTypes, nil
+}
+
+func (client *Client) ReadDestinationType(id int) (*DestinationType, error, int) {
+	u := *client.restURL
+	u.Path = u.Path + "target-types/" + strconv.Itoa(id) + "/"
+	response, err := client.sendRequestRead(u)
 	if err != nil {
-		return nil, err
+		return nil, err, 0
 	}
+
+	resMap := &DestinationType{}
 	if !responseOK(response) {
 		defer response.Body.Close()
 		body, _ := ioutil.ReadAll(response.Body)
-		return nil, errorString{"Failed querying destination types. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
+		return resMap, errorString{"Failed reading destination type. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}, response.StatusCode
 	}
-	resMap := &DestinationTypeResults{}
+
 	err = getJSON(response, resMap)
 	if err != nil {
-		return nil, err
+		return nil, err, response.StatusCode
 	}
-	return resMap.Results, nil
+
+	return resMap, nil, response.StatusCode
 }
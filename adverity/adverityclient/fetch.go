@@ -26,6 +26,9 @@ func (client *Client) DoFetch(fetchConfig FetchConfig, id string) (*FetchRespons
 	}
 	resMap := &FetchResponse{}
 	err = getJSON(response, resMap)
+	if err != nil {
+		return nil, err
+	}
 	return resMap, nil
 }
 
@@ -174,3 +177,57 @@ func (client *Client) ReadJob(ID int) (*Job, error, int) {
 	}
 	return resMap, nil, 0
 }
+
+func (client *Client) ListJobs(filters []Query, limit int) ([]Job, error) {
+	u := *client.restURL
+	u.Path = u.Path + "jobs/"
+	page := 1
+	queries := append([]Query{
+		{
+			Key:   "page",
+			Value: strconv.Itoa(page),
+		},
+		{
+			Key:   "ordering",
+			Value: "-job_start",
+		},
+	}, filters...)
+	if limit > 0 {
+		queries = append(queries, Query{
+			Key:   "page_size",
+			Value: strconv.Itoa(limit),
+		})
+	}
+
+	jobs := []Job{}
+	for {
+		response, err := client.sendRequestQuery(u, queries)
+		if err != nil {
+			return nil, err
+		}
+		resultsMap := &JobResults{}
+		if !responseOK(response) {
+			defer response.Body.Close()
+			body, _ := ioutil.ReadAll(response.Body)
+			return nil, errorString{"Failed listing jobs. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
+		}
+		err = getJSON(response, resultsMap)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, resultsMap.Results...)
+		if resultsMap.Next == "" || (limit > 0 && len(jobs) >= limit) {
+			break
+		} else {
+			page = page + 1
+			queries[0] = Query{
+				Key:   "page",
+				Value: strconv.Itoa(page),
+			}
+		}
+	}
+	if limit > 0 && len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	return jobs, nil
+}
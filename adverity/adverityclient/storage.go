@@ -70,7 +70,7 @@ func (client *Client) UpdateStorage(conf StorageConfig, id string) (*http.Respon
 	if !responseOK(response) {
 		defer response.Body.Close()
 		body, _ := ioutil.ReadAll(response.Body)
-		return response, errorString{"Failed deleting workspace. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
+		return response, errorString{"Failed updating storage. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
 	}
 
 	return response, nil
@@ -93,3 +93,134 @@ func (client *Client) DeleteStorage(id string) (*http.Response, error) {
 	return response, nil
 
 }
+
+func (client *Client) ReadDatalake(id string) (*Datalake, error, int) {
+	u := *client.restURL
+	u.Path = u.Path + "datalakes/" + id + "/"
+	response, err := client.sendRequestRead(u)
+	if err != nil {
+		return nil, err, 0
+	}
+
+	resMap := &Datalake{}
+	if !responseOK(response) {
+		defer response.Body.Close()
+		body, _ := ioutil.ReadAll(response.Body)
+		return resMap, errorString{"Failed reading datalake. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}, response.StatusCode
+	}
+
+	err = getJSON(response, resMap)
+	if err != nil {
+		return nil, err, response.StatusCode
+	}
+
+	return resMap, nil, response.StatusCode
+
+}
+
+func (client *Client) CreateDatalake(conf DatalakeConfig) (*Datalake, error) {
+	u := *client.restURL
+	u.Path = u.Path + "datalakes/"
+	body, _ := json.Marshal(conf)
+
+	response, err := client.sendRequestCreate(u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	resMap := &Datalake{}
+	if !responseOK(response) {
+		defer response.Body.Close()
+		body, _ := ioutil.ReadAll(response.Body)
+		return resMap, errorString{"Failed creating datalake. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
+	}
+
+	err = getJSON(response, resMap)
+	if err != nil {
+		return nil, err
+	}
+
+	return resMap, nil
+}
+
+func (client *Client) UpdateDatalake(conf DatalakeConfig, id string) (*http.Response, error) {
+	u := *client.restURL
+	u.Path = u.Path + "datalakes/" + id + "/"
+
+	body, _ := json.Marshal(&conf)
+	response, err := client.sendRequestUpdate(u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if !responseOK(response) {
+		defer response.Body.Close()
+		body, _ := ioutil.ReadAll(response.Body)
+		return response, errorString{"Failed updating datalake. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
+	}
+
+	return response, nil
+
+}
+
+func (client *Client) DeleteDatalake(id string) (*http.Response, error) {
+	u := *client.restURL
+	u.Path = u.Path + "datalakes/" + id + "/"
+	response, err := client.sendRequestDelete(u)
+	if err != nil {
+		return nil, err
+	}
+	if !responseOK(response) {
+		defer response.Body.Close()
+		body, _ := ioutil.ReadAll(response.Body)
+		return response, errorString{"Failed deleting datalake. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
+	}
+
+	return response, nil
+
+}
+
+func (client *Client) ListDatalakes(searchTerm string) ([]Datalake, error) {
+	u := *client.restURL
+	u.Path = u.Path + "datalakes/"
+	page := 1
+	queries := []Query{
+		{
+			Key:   "page",
+			Value: strconv.Itoa(page),
+		},
+	}
+	if searchTerm != "" {
+		queries = append(queries, Query{
+			Key:   "search",
+			Value: searchTerm,
+		})
+	}
+
+	datalakes := []Datalake{}
+	for {
+		response, err := client.sendRequestQuery(u, queries)
+		if err != nil {
+			return nil, err
+		}
+		resultsMap := &DatalakeResults{}
+		if !responseOK(response) {
+			defer response.Body.Close()
+			body, _ := ioutil.ReadAll(response.Body)
+			return nil, errorString{"Failed listing datalakes. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
+		}
+		err = getJSON(response, resultsMap)
+		if err != nil {
+			return nil, err
+		}
+		datalakes = append(datalakes, resultsMap.Results...)
+		if resultsMap.Next == "" {
+			break
+		} else {
+			page = page + 1
+			queries[0] = Query{
+				Key:   "page",
+				Value: strconv.Itoa(page),
+			}
+		}
+	}
+	return datalakes, nil
+}
@@ -21,20 +21,23 @@ func (e errorString) Error() string {
 }
 
 type CreateWorkspaceConfig struct {
-	DatalakeID string `json:"datalake_id,omitempty" url:"datalake_id,omitempty"`
-	Name       string `json:"name,omitempty" url:"name,omitempty"`
-	ParentID   int    `json:"parent_id,omitempty" url:"parent_id,omitempty"`
+	DatalakeID                string `json:"datalake_id,omitempty" url:"datalake_id,omitempty"`
+	Name                      string `json:"name,omitempty" url:"name,omitempty"`
+	ParentID                  int    `json:"parent_id,omitempty" url:"parent_id,omitempty"`
+	DefaultManageExtractNames *bool  `json:"default_manage_extract_names,omitempty" url:"default_manage_extract_names,omitempty"`
 }
 
 type UpdateWorkspaceConfig struct {
-	DatalakeID string `json:"datalake_id,omitempty" url:"datalake_id,omitempty"`
-	ParentID   int    `json:"parent_id,omitempty" url:"parent_id,omitempty"`
-	StackSlug  string `json:"stack_slug,omitempty" url:"stack_slug,omitempty"`
-	Name       string `json:"name,omitempty" url:"name,omitempty"`
+	DatalakeID                string `json:"datalake_id,omitempty" url:"datalake_id,omitempty"`
+	ParentID                  int    `json:"parent_id,omitempty" url:"parent_id,omitempty"`
+	Name                      string `json:"name,omitempty" url:"name,omitempty"`
+	DefaultManageExtractNames *bool  `json:"default_manage_extract_names,omitempty" url:"default_manage_extract_names,omitempty"`
 }
 
-type DeleteWorkspaceConfig struct {
-	StackSlug string `json:"stack_slug,omitempty" url:"stack_slug,omitempty"`
+type WorkspaceMemberConfig struct {
+	User  int    `json:"user,omitempty"`
+	Group int    `json:"group,omitempty"`
+	Role  string `json:"role"`
 }
 
 type Parameters struct {
@@ -104,18 +107,48 @@ type DataStreamEnablingConfig struct {
 }
 
 type DestinationConfig struct {
-	Name              string `json:"name"`
-	Stack             int    `json:"stack"`
-	ProjectID         string `json:"project"`
-	DatasetID         string `json:"dataset"`
-	Auth              int    `json:"auth"`
-	SchemaMapping     bool   `json:"schema_mapping"`
-	HeadersFormatting int    `json:"headers_formatting"`
+	Name                   string `json:"name"`
+	Stack                  int    `json:"stack"`
+	ProjectID              string `json:"project,omitempty"`
+	DatasetID              string `json:"dataset,omitempty"`
+	Account                string `json:"account,omitempty"`
+	Warehouse              string `json:"warehouse,omitempty"`
+	Role                   string `json:"role,omitempty"`
+	Host                   string `json:"host,omitempty"`
+	Port                   int    `json:"port,omitempty"`
+	Database               string `json:"database,omitempty"`
+	Schema                 string `json:"schema,omitempty"`
+	Bucket                 string `json:"bucket,omitempty"`
+	Path                   string `json:"path,omitempty"`
+	FileFormat             string `json:"file_format,omitempty"`
+	Auth                   int    `json:"auth"`
+	SchemaMapping          bool   `json:"schema_mapping"`
+	HeadersFormatting      int    `json:"headers_formatting"`
+	ForceString            *bool  `json:"force_string,omitempty"`
+	FormatHeaders          *bool  `json:"format_headers,omitempty"`
+	ColumnNamesToLowercase *bool  `json:"column_names_to_lowercase,omitempty"`
 }
 
 type DestinationMappingConfig struct {
-	Datastream int    `json:"datastream,omitempty"`
-	TableName  string `json:"table_name,omitempty"`
+	Datastream       int      `json:"datastream,omitempty"`
+	TableName        string   `json:"table_name,omitempty"`
+	PartitionColumn  string   `json:"partition_column,omitempty"`
+	PartitionType    string   `json:"partition_type,omitempty"`
+	ClusteringFields []string `json:"clustering_fields,omitempty"`
+	WriteMode        string   `json:"write_mode,omitempty"`
+	TableExpiration  int      `json:"table_expiration_days,omitempty"`
+}
+
+// DestinationMappingUpdateConfig is sent when updating a destination mapping. Its optional settings are not omitted when empty,
+// so removing them in Terraform also removes them in Adverity. A nil pointer is sent as null.
+type DestinationMappingUpdateConfig struct {
+	Datastream       int      `json:"datastream,omitempty"`
+	TableName        string   `json:"table_name,omitempty"`
+	PartitionColumn  *string  `json:"partition_column"`
+	PartitionType    *string  `json:"partition_type"`
+	ClusteringFields []string `json:"clustering_fields"`
+	WriteMode        string   `json:"write_mode,omitempty"`
+	TableExpiration  *int     `json:"table_expiration_days"`
 }
 
 type Workspace struct {
@@ -147,6 +180,34 @@ type Workspace struct {
 	Created                   string `json:"created"`
 }
 
+type WorkspaceResults struct {
+	Count    int         `json:"count"`
+	Next     string      `json:"next"`
+	Previous string      `json:"previous"`
+	Results  []Workspace `json:"results"`
+}
+
+type DatastreamResults struct {
+	Count    int          `json:"count"`
+	Next     string       `json:"next"`
+	Previous string       `json:"previous"`
+	Results  []Datastream `json:"results"`
+}
+
+type ConnectionResults struct {
+	Count    int          `json:"count"`
+	Next     string       `json:"next"`
+	Previous string       `json:"previous"`
+	Results  []Connection `json:"results"`
+}
+
+type WorkspaceMember struct {
+	ID    int    `json:"id"`
+	User  int    `json:"user"`
+	Group int    `json:"group"`
+	Role  string `json:"role"`
+}
+
 type Connection struct {
 	ID            int    `json:"id"`
 	Name          string `json:"name"`
@@ -168,6 +229,16 @@ type Destination struct {
 	ColumnNamesToLowercase  bool   `json:"column_names_to_lowercase"`
 	Project                 string `json:"project"`
 	Dataset                 string `json:"dataset"`
+	Account                 string `json:"account"`
+	Warehouse               string `json:"warehouse"`
+	Role                    string `json:"role"`
+	Host                    string `json:"host"`
+	Port                    int    `json:"port"`
+	Database                string `json:"database"`
+	Schema                  string `json:"schema"`
+	Bucket                  string `json:"bucket"`
+	Path                    string `json:"path"`
+	FileFormat              string `json:"file_format"`
 	HeadersFormatting       int    `json:"headers_formatting"`
 	Stack                   int    `json:"stack"`
 	Auth                    int    `json:"auth"`
@@ -212,19 +283,57 @@ type Datastream struct {
 }
 
 type StorageConfig struct {
-	Name  string `json:"name"`
-	Stack int    `json:"stack,omitempty"`
-	URL   string `json:"url"`
-	Auth  int    `json:"auth"`
+	Name                 string `json:"name"`
+	Stack                int    `json:"stack,omitempty"`
+	URL                  string `json:"url"`
+	Auth                 int    `json:"auth"`
+	BackupExisting       bool   `json:"backup_existing"`
+	ProjectID            string `json:"project_id,omitempty"`
+	Region               string `json:"region,omitempty"`
+	ServerSideEncryption string `json:"server_side_encryption,omitempty"`
+	KMSKeyID             string `json:"kms_key_id,omitempty"`
+	AccountName          string `json:"account_name,omitempty"`
+	AccessTier           string `json:"access_tier,omitempty"`
+	Port                 int    `json:"port,omitempty"`
+	HostKey              string `json:"host_key,omitempty"`
 }
 
 type Storage struct {
-	ID             int    `json:"id"`
-	Name           string `json:"name"`
-	Stack          int    `json:"stack"`
-	URL            string `json:"url"`
-	BackupExisting bool   `json:"backup_existing"`
-	Auth           int    `json:"auth"`
+	ID                   int    `json:"id"`
+	Name                 string `json:"name"`
+	Stack                int    `json:"stack"`
+	URL                  string `json:"url"`
+	BackupExisting       bool   `json:"backup_existing"`
+	Auth                 int    `json:"auth"`
+	ProjectID            string `json:"project_id"`
+	Region               string `json:"region"`
+	ServerSideEncryption string `json:"server_side_encryption"`
+	KMSKeyID             string `json:"kms_key_id"`
+	AccountName          string `json:"account_name"`
+	AccessTier           string `json:"access_tier"`
+	Port                 int    `json:"port"`
+	HostKey              string `json:"host_key"`
+}
+
+type DatalakeConfig struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Storage     int    `json:"storage"`
+}
+
+type Datalake struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Storage     int    `json:"storage"`
+	URL         string `json:"url"`
+}
+
+type DatalakeResults struct {
+	Count    int        `json:"count"`
+	Next     string     `json:"next"`
+	Previous string     `json:"previous"`
+	Results  []Datalake `json:"results"`
 }
 
 type AuthUrl struct {
@@ -235,10 +344,31 @@ type AuthUrl struct {
 }
 
 type DestinationMapping struct {
-	ID         int    `json:"id"`
-	Target     int    `json:"target"`
-	Datastream int    `json:"datastream"`
-	TableName  string `json:"table_name"`
+	ID               int      `json:"id"`
+	Target           int      `json:"target"`
+	Datastream       int      `json:"datastream"`
+	TableName        string   `json:"table_name"`
+	PartitionColumn  string   `json:"partition_column"`
+	PartitionType    string   `json:"partition_type"`
+	ClusteringFields []string `json:"clustering_fields"`
+	WriteMode        string   `json:"write_mode"`
+	TableExpiration  int      `json:"table_expiration_days"`
+	// DestinationType is not part of the API response, it is filled in when listing the mappings of a datastream
+	DestinationType int `json:"-"`
+}
+
+type DestinationMappingResults struct {
+	Count    int                  `json:"count"`
+	Next     string               `json:"next"`
+	Previous string               `json:"previous"`
+	Results  []DestinationMapping `json:"results"`
+}
+
+type DestinationResults struct {
+	Count    int           `json:"count"`
+	Next     string        `json:"next"`
+	Previous string        `json:"previous"`
+	Results  []Destination `json:"results"`
 }
 
 type Schedule struct {
@@ -328,6 +458,13 @@ type Job struct {
 	Manual     bool    `json:"manual"`
 }
 
+type JobResults struct {
+	Count    int    `json:"count"`
+	Next     string `json:"next"`
+	Previous string `json:"previous"`
+	Results  []Job  `json:"results"`
+}
+
 type Issue struct {
 	Message               string `json:"message"`
 	TypeLabel             string `json:"type_label"`
@@ -382,6 +519,8 @@ type DestinationType struct {
 	Slug    string `json:"slug"`
 	URL     string `json:"url"`
 	Targets string `json:"targets"`
+	// IsSchemaMappingRequired is only returned when reading a single destination type
+	IsSchemaMappingRequired bool `json:"is_schema_mapping_required"`
 }
 
 type DestinationTypeResults struct {
@@ -392,68 +531,50 @@ type DestinationTypeResults struct {
 }
 
 type ConnectionOptions struct {
-	Name        string                      `json:"name"`
-	Description string                      `json:"description"`
-	Renders     []string                    `json:"renders"`
-	Parses      []string                    `json:"parses"`
-	Actions     map[string]ConnectionAction `json:"actions"`
+	Name        string                                `json:"name"`
+	Description string                                `json:"description"`
+	Renders     []string                              `json:"renders"`
+	Parses      []string                              `json:"parses"`
+	Actions     map[string]map[string]ConnectionField `json:"actions"`
 }
 
-type ConnectionAction struct {
-	NoValidate ConnectionActionNoValidate `json:"no_validate"`
-	Name       ConnectionActionName       `json:"name"`
-	Stack      ConnectionActionChoice     `json:"stack"`
-	App        ConnectionActionChoice     `json:"app"`
+// ConnectionField describes a single field of an action (e.g. POST) on the connections of a connection type.
+type ConnectionField struct {
+	Type      string        `json:"type"`
+	Required  bool          `json:"required"`
+	ReadOnly  bool          `json:"read_only"`
+	Label     string        `json:"label"`
+	HelpText  string        `json:"help_text"`
+	MaxLength int           `json:"max_length"`
+	Choices   []FieldChoice `json:"choices"`
 }
 
-type ConnectionActionNoValidate struct {
-	Type     string `json:"type"`
-	Required bool   `json:"required"`
-	ReadOnly bool   `json:"read_only"`
-	Label    string `json:"label"`
-}
-
-type ConnectionActionName struct {
-	Type      string `json:"string"`
-	Required  bool   `json:"required"`
-	ReadOnly  bool   `json:"read_only"`
-	Label     string `json:"label"`
-	MaxLength int    `json:"max_length"`
-}
-
-type ConnectionActionChoice struct {
-	Type     string             `json:"string"`
-	Required bool               `json:"required"`
-	ReadOnly bool               `json:"read_only"`
-	Label    string             `json:"label"`
-	Choices  []ValueDisplayName `json:"choices"`
-}
-
-type ValueDisplayName struct {
-	Value       int    `json:"value"`
-	DisplayName string `json:"display_name"`
+// FieldChoice is one of the allowed values of a field. The value is a number for apps, but can be a string for other fields.
+type FieldChoice struct {
+	Value       interface{} `json:"value"`
+	DisplayName string      `json:"display_name"`
 }
 
 type SchemaElementMode struct {
-	Mode string `json:"mode"`
-	Name string `json:"name"`
-	Type string `json:"type"`
-}
-
-type SchemaElementNoMode struct {
-	Name   string `json:"name"`
-	Type   string `json:"type"`
-	Mapped bool   `json:"mapped,omitempty"`
+	Mode   string              `json:"mode,omitempty"`
+	Name   string              `json:"name"`
+	Type   string              `json:"type"`
+	Fields []SchemaElementMode `json:"fields,omitempty"`
+	Mapped bool                `json:"mapped,omitempty"`
 }
 
 type ColumnConfig struct {
 	Name         string        `json:"name"`
 	Type         string        `json:"datatype"`
+	IsKeyColumn  bool          `json:"is_key_column"`
+	Removed      bool          `json:"removed"`
 	TargetColumn *TargetColumn `json:"target_column,omitempty"`
+	// ClearTargetColumn sends an explicit null target column when TargetColumn is nil, removing an existing mapping
+	ClearTargetColumn bool `json:"-"`
 }
 
 type TargetColumn struct {
-	ID       int    `json:"id"`
+	ID       int    `json:"id,omitempty"`
 	Name     string `json:"name"`
 	Usage    string `json:"usage"`
 	Datatype string `json:"datatype"`
@@ -57,9 +57,9 @@ func (client *Client) CreateWorkspace(conf CreateWorkspaceConfig) (*Workspace, e
 	return resMap, nil
 }
 
-func (client *Client) UpdateWorkspace(conf UpdateWorkspaceConfig) (*http.Response, error) {
+func (client *Client) UpdateWorkspace(conf UpdateWorkspaceConfig, id string) (*http.Response, error) {
 	u := *client.restURL
-	u.Path = u.Path + "stacks/" + conf.StackSlug + "/"
+	u.Path = u.Path + "stacks/" + id + "/"
 
 	body, _ := json.Marshal(conf)
 	response, err := client.sendRequestUpdate(u, bytes.NewReader(body))
@@ -69,16 +69,16 @@ func (client *Client) UpdateWorkspace(conf UpdateWorkspaceConfig) (*http.Respons
 	if !responseOK(response) {
 		defer response.Body.Close()
 		body, _ := ioutil.ReadAll(response.Body)
-		return response, errorString{"Failed deleting workspace. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
+		return response, errorString{"Failed updating workspace. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
 	}
 
 	return response, nil
 
 }
 
-func (client *Client) DeleteWorkspace(conf DeleteWorkspaceConfig) (*http.Response, error) {
+func (client *Client) DeleteWorkspace(id string) (*http.Response, error) {
 	u := *client.restURL
-	u.Path = u.Path + "stacks/" + conf.StackSlug + "/"
+	u.Path = u.Path + "stacks/" + id + "/"
 	response, err := client.sendRequestDelete(u)
 	if err != nil {
 		return nil, err
@@ -92,3 +92,126 @@ func (client *Client) DeleteWorkspace(conf DeleteWorkspaceConfig) (*http.Respons
 	return response, nil
 
 }
+
+func (client *Client) ReadWorkspaceMember(workspaceID string, id string) (*WorkspaceMember, error, int) {
+	u := *client.restURL
+	u.Path = u.Path + "stacks/" + workspaceID + "/members/" + id + "/"
+
+	response, err := client.sendRequestRead(u)
+	if err != nil {
+		return nil, err, 0
+	}
+
+	resMap := &WorkspaceMember{}
+	if !responseOK(response) {
+		defer response.Body.Close()
+		body, _ := ioutil.ReadAll(response.Body)
+		return resMap, errorString{"Failed reading workspace member. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}, response.StatusCode
+	}
+
+	err = getJSON(response, resMap)
+	if err != nil {
+		return nil, err, response.StatusCode
+	}
+
+	return resMap, nil, response.StatusCode
+}
+
+func (client *Client) CreateWorkspaceMember(conf WorkspaceMemberConfig, workspaceID string) (*WorkspaceMember, error) {
+	u := *client.restURL
+	u.Path = u.Path + "stacks/" + workspaceID + "/members/"
+
+	body, _ := json.Marshal(conf)
+	response, err := client.sendRequestCreate(u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	resMap := &WorkspaceMember{}
+	if !responseOK(response) {
+		defer response.Body.Close()
+		body, _ := ioutil.ReadAll(response.Body)
+		return resMap, errorString{"Failed creating workspace member. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
+	}
+
+	err = getJSON(response, resMap)
+	if err != nil {
+		return nil, err
+	}
+
+	return resMap, nil
+}
+
+func (client *Client) UpdateWorkspaceMember(conf WorkspaceMemberConfig, workspaceID string, id string) (*http.Response, error) {
+	u := *client.restURL
+	u.Path = u.Path + "stacks/" + workspaceID + "/members/" + id + "/"
+
+	body, _ := json.Marshal(conf)
+	response, err := client.sendRequestUpdate(u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if !responseOK(response) {
+		defer response.Body.Close()
+		body, _ := ioutil.ReadAll(response.Body)
+		return response, errorString{"Failed updating workspace member. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
+	}
+
+	return response, nil
+}
+
+func (client *Client) DeleteWorkspaceMember(workspaceID string, id string) (*http.Response, error) {
+	u := *client.restURL
+	u.Path = u.Path + "stacks/" + workspaceID + "/members/" + id + "/"
+	response, err := client.sendRequestDelete(u)
+	if err != nil {
+		return nil, err
+	}
+	if !responseOK(response) {
+		defer response.Body.Close()
+		body, _ := ioutil.ReadAll(response.Body)
+		return response, errorString{"Failed deleting workspace member. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
+	}
+
+	return response, nil
+}
+
+func (client *Client) ListWorkspaces() ([]Workspace, error) {
+	u := *client.restURL
+	u.Path = u.Path + "stacks/"
+	page := 1
+	queries := []Query{
+		{
+			Key:   "page",
+			Value: strconv.Itoa(page),
+		},
+	}
+
+	workspaces := []Workspace{}
+	for {
+		response, err := client.sendRequestQuery(u, queries)
+		if err != nil {
+			return nil, err
+		}
+		resultsMap := &WorkspaceResults{}
+		if !responseOK(response) {
+			defer response.Body.Close()
+			body, _ := ioutil.ReadAll(response.Body)
+			return nil, errorString{"Failed listing workspaces. Got back statuscode: " + strconv.Itoa(response.StatusCode) + " with body: " + string(body)}
+		}
+		err = getJSON(response, resultsMap)
+		if err != nil {
+			return nil, err
+		}
+		workspaces = append(workspaces, resultsMap.Results...)
+		if resultsMap.Next == "" {
+			break
+		} else {
+			page = page + 1
+			queries[0] = Query{
+				Key:   "page",
+				Value: strconv.Itoa(page),
+			}
+		}
+	}
+	return workspaces, nil
+}
@@ -0,0 +1,168 @@
+package adverity
+
+import (
+	"context"
+	"sort"
+	"strconv"
+
+	"github.com/devoteamgcloud/adverityclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceAdverityConnectionApps() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"connection_type_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "The connection type ID to list the apps and fields for.",
+			},
+			"apps": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"value": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the app, to be used as the 'app' connection parameter.",
+						},
+						"display_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the app, as used by the selector of the adverity_connection_app data source.",
+						},
+					},
+				},
+				Description: "All apps a connection of this type can be authorised through. Empty if the connection type doesn't use apps.",
+			},
+			"fields": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the field, to be used as key in connection_parameters or sensitive_parameters.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the field, for example string, boolean or choice.",
+						},
+						"required": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the field is required to create a connection.",
+						},
+						"read_only": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the field is read only.",
+						},
+						"label": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The label of the field in Adverity.",
+						},
+						"help_text": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The help text of the field in Adverity.",
+						},
+						"max_length": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The maximum length of the value. 0 if there is no maximum.",
+						},
+						"choices": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"value": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The value of the choice.",
+									},
+									"display_name": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The name of the choice.",
+									},
+								},
+							},
+							Description: "The allowed values of the field, if it is a choice field.",
+						},
+					},
+				},
+				Description: "All fields that can be sent when creating a connection of this type, sorted by name.",
+			},
+		},
+		ReadContext: datasourceConnectionApps,
+		Description: "This data source lists the apps and the fields of a connection type, so the connection_parameters a connection type requires can be discovered.",
+	}
+}
+
+func datasourceConnectionApps(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	connectionTypeID := d.Get("connection_type_id").(int)
+	providerConfig := m.(*config)
+	client := *providerConfig.Client
+	options, err := client.ReadConnectionOptions(connectionTypeID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	postFields := options.Actions["POST"]
+	apps := []interface{}{}
+	for _, choice := range postFields["app"].Choices {
+		value, err := choice.IntValue()
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		apps = append(apps, map[string]interface{}{
+			"value":        value,
+			"display_name": choice.DisplayName,
+		})
+	}
+	if err := d.Set("apps", apps); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("fields", flattenConnectionFields(postFields)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(strconv.Itoa(connectionTypeID))
+	return diags
+}
+
+func flattenConnectionFields(fields map[string]adverityclient.ConnectionField) []interface{} {
+	names := []string{}
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	flattened := make([]interface{}, len(names), len(names))
+	for i, name := range names {
+		field := fields[name]
+		choices := []interface{}{}
+		for _, choice := range field.Choices {
+			choices = append(choices, map[string]interface{}{
+				"value":        choice.StringValue(),
+				"display_name": choice.DisplayName,
+			})
+		}
+		fd := make(map[string]interface{})
+		fd["name"] = name
+		fd["type"] = field.Type
+		fd["required"] = field.Required
+		fd["read_only"] = field.ReadOnly
+		fd["label"] = field.Label
+		fd["help_text"] = field.HelpText
+		fd["max_length"] = field.MaxLength
+		fd["choices"] = choices
+		flattened[i] = fd
+	}
+	return flattened
+}
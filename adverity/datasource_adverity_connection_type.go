@@ -3,6 +3,7 @@ package adverity
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/devoteamgcloud/adverityclient"
 	"github.com/hashicorp/go-uuid"
@@ -11,24 +12,44 @@ import (
 )
 
 func datasourceAdverityConnectionType() *schema.Resource {
-	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
-			"api_search_term": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The search term corresponding to what you would search for in the API.",
-			},
-			"slug_search_term": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The slug of the connection type you're looking for.",
-			},
-			"connection_type_id": {
-				Type:        schema.TypeInt,
-				Computed:    true,
-				Description: "The ID of the connection type for your instance.",
-			},
+	dataSchema := map[string]*schema.Schema{
+		"api_search_term": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			AtLeastOneOf: []string{"api_search_term", "slug_search_term"},
+			Description:  "The search term corresponding to what you would search for in the API. If multiple connection types are found, slug_search_term is used to pick one.",
+		},
+		"slug_search_term": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			AtLeastOneOf: []string{"api_search_term", "slug_search_term"},
+			Description:  "The exact slug of the connection type you're looking for. Can be used without api_search_term.",
+		},
+		"error_on_deprecated": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "If set to true, an error is returned when the connection type found is deprecated. Otherwise a warning is shown.",
+		},
+		"strict": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "If set to true, an error is returned when multiple connection types match the search terms. Otherwise a warning is shown and the first match is used.",
+		},
+		"connection_type_id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The ID of the connection type for your instance.",
 		},
+	}
+	for name, attribute := range connectionTypeSchema() {
+		if name != "id" {
+			dataSchema[name] = attribute
+		}
+	}
+	return &schema.Resource{
+		Schema:      dataSchema,
 		ReadContext: datasourceConnectionType,
 		Description: "This data source will look up the connection type ID, which is needed to create a connection of the correct type. This ID changes depending on the Adverity instance.",
 	}
@@ -37,40 +58,89 @@ func datasourceAdverityConnectionType() *schema.Resource {
 func datasourceConnectionType(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	searchTerm := d.Get("api_search_term").(string)
+	slugSearchTerm := d.Get("slug_search_term").(string)
 	providerConfig := m.(*config)
 	client := *providerConfig.Client
-	results, err := client.LookupConnectionTypes(searchTerm)
+	var results []adverityclient.ConnectionType
+	var err error
+	if searchTerm != "" {
+		results, err = client.LookupConnectionTypes(searchTerm)
+	} else {
+		// Searching on the slug narrows the results down in most cases, the exact match is picked below
+		results, err = client.ListConnectionTypes(slugSearchTerm)
+		if err == nil && len(filterConnectionTypesBySlug(results, slugSearchTerm)) == 0 {
+			results, err = client.ListConnectionTypes("")
+		}
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	finalResults := []adverityclient.ConnectionType{}
-	if len(results) <= 0 {
+	if searchTerm == "" {
+		finalResults = filterConnectionTypesBySlug(results, slugSearchTerm)
+		if len(finalResults) <= 0 {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("No connection type was found with slug %s", slugSearchTerm),
+			})
+		}
+	} else if len(results) <= 0 {
 		return append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  fmt.Sprintf("No results were found for search term %s", searchTerm),
 		})
 	} else if len(results) > 1 {
-		slugSearchTerm := d.Get("slug_search_term").(string)
-		for _, result := range results {
-			if result.Slug == slugSearchTerm {
-				finalResults = append(finalResults, result)
-			}
+		if slugSearchTerm == "" {
+			return append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Multiple results (%d) were found for search term %s, set slug_search_term to pick one of: %s", len(results), searchTerm, strings.Join(connectionTypeSlugs(results), ", ")),
+			})
 		}
+		finalResults = filterConnectionTypesBySlug(results, slugSearchTerm)
 		if len(finalResults) <= 0 {
 			return append(diags, diag.Diagnostic{
 				Severity: diag.Error,
 				Summary:  fmt.Sprintf("No results were found for slug search term %s", slugSearchTerm),
 			})
-		} else if len(finalResults) > 1 {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Warning,
-				Summary:  fmt.Sprintf("Multiple results (%d) were found for search term %s and slug search term %s, the first result will be selected (%s)", len(finalResults), searchTerm, slugSearchTerm, finalResults[0].Name),
-			})
 		}
 	} else {
 		finalResults = results
 	}
-	d.Set("connection_type_id", finalResults[0].ID)
+	if len(finalResults) > 1 {
+		multiple := diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Multiple results (%d) were found for search term %s and slug search term %s, the first result will be selected (%s)", len(finalResults), searchTerm, slugSearchTerm, finalResults[0].Name),
+			Detail:   fmt.Sprintf("Found: %s", strings.Join(connectionTypeSlugs(finalResults), ", ")),
+		}
+		if d.Get("strict").(bool) {
+			multiple.Severity = diag.Error
+		}
+		diags = append(diags, multiple)
+		if diags.HasError() {
+			return diags
+		}
+	}
+	connectionType := finalResults[0]
+	if connectionType.IsDeprecated {
+		deprecated := diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Connection type %s (%s) is deprecated", connectionType.Name, connectionType.Slug),
+			Detail:   "Deprecated connection types may stop working. Consider switching to a connection type that replaces it.",
+		}
+		if d.Get("error_on_deprecated").(bool) {
+			deprecated.Severity = diag.Error
+		}
+		diags = append(diags, deprecated)
+		if diags.HasError() {
+			return diags
+		}
+	}
+	d.Set("connection_type_id", connectionType.ID)
+	for name, value := range flattenConnectionType(connectionType) {
+		if name != "id" {
+			d.Set(name, value)
+		}
+	}
 	id, err := uuid.GenerateUUID()
 	if err != nil {
 		return append(diags, diag.FromErr(err)...)
@@ -78,3 +148,92 @@ func datasourceConnectionType(ctx context.Context, d *schema.ResourceData, m int
 	d.SetId(id)
 	return diags
 }
+
+func filterConnectionTypesBySlug(connectionTypes []adverityclient.ConnectionType, slug string) []adverityclient.ConnectionType {
+	filtered := []adverityclient.ConnectionType{}
+	for _, connectionType := range connectionTypes {
+		if connectionType.Slug == slug {
+			filtered = append(filtered, connectionType)
+		}
+	}
+	return filtered
+}
+
+func connectionTypeSlugs(connectionTypes []adverityclient.ConnectionType) []string {
+	slugs := []string{}
+	for _, connectionType := range connectionTypes {
+		slugs = append(slugs, connectionType.Slug)
+	}
+	return slugs
+}
+
+// connectionTypeSchema returns the computed attributes describing a connection type.
+func connectionTypeSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The ID of the connection type for your instance.",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the connection type.",
+		},
+		"slug": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The slug of the connection type.",
+		},
+		"url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The API url of the connection type.",
+		},
+		"categories": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Description: "The categories of the connection type.",
+		},
+		"keywords": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+			Description: "The keywords of the connection type.",
+		},
+		"is_deprecated": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the connection type is deprecated.",
+		},
+		"logo_url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The url of the logo of the connection type.",
+		},
+		"create_url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The url to create a connection of this type in Adverity.",
+		},
+	}
+}
+
+func flattenConnectionType(connectionType adverityclient.ConnectionType) map[string]interface{} {
+	ct := make(map[string]interface{})
+	ct["id"] = connectionType.ID
+	ct["name"] = connectionType.Name
+	ct["slug"] = connectionType.Slug
+	ct["url"] = connectionType.URL
+	ct["categories"] = connectionType.Categories
+	ct["keywords"] = connectionType.Keywords
+	ct["is_deprecated"] = connectionType.IsDeprecated
+	ct["logo_url"] = connectionType.LogoURL
+	ct["create_url"] = connectionType.CreateURL
+	return ct
+}
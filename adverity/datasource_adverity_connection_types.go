@@ -0,0 +1,84 @@
+package adverity
+
+import (
+	"context"
+	"strings"
+
+	"github.com/devoteamgcloud/adverityclient"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceAdverityConnectionTypes() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"search": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return connection types matching this search term, as you would search for in the API.",
+			},
+			"category": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return connection types in this category (case insensitive).",
+			},
+			"include_deprecated": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If set to true, deprecated connection types are returned as well.",
+			},
+			"connection_types": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: connectionTypeSchema(),
+				},
+				Description: "The connection types matching the given filters.",
+			},
+		},
+		ReadContext: datasourceConnectionTypes,
+		Description: "This data source lists the connection types available on the Adverity instance, with their metadata.",
+	}
+}
+
+func datasourceConnectionTypes(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	category := strings.ToLower(d.Get("category").(string))
+	includeDeprecated := d.Get("include_deprecated").(bool)
+	providerConfig := m.(*config)
+	client := *providerConfig.Client
+	results, err := client.ListConnectionTypes(d.Get("search").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	connectionTypes := []interface{}{}
+	for _, connectionType := range results {
+		if connectionType.IsDeprecated && !includeDeprecated {
+			continue
+		}
+		if category != "" && !hasCategory(connectionType, category) {
+			continue
+		}
+		connectionTypes = append(connectionTypes, flattenConnectionType(connectionType))
+	}
+	if err := d.Set("connection_types", connectionTypes); err != nil {
+		return diag.FromErr(err)
+	}
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	d.SetId(id)
+	return diags
+}
+
+func hasCategory(connectionType adverityclient.ConnectionType, category string) bool {
+	for _, connectionTypeCategory := range connectionType.Categories {
+		if strings.ToLower(connectionTypeCategory) == category {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package adverity
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/devoteamgcloud/adverityclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceAdverityDatalake() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			NAME: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The exact name of the datalake to look up.",
+			},
+			DATALAKE_ID: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the datalake, to be used as the datalake_id of a workspace.",
+			},
+			STORAGE_ID: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the storage the datalake stores its data in.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The description of the datalake.",
+			},
+			URL: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The API url of the datalake.",
+			},
+		},
+		ReadContext: datasourceDatalakeRead,
+		Description: "This data source looks up a datalake by its name. Fails if no datalake or more than one datalake has that name.",
+	}
+}
+
+func datasourceDatalakeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	providerConfig := m.(*config)
+
+	client := *providerConfig.Client
+
+	name := d.Get(NAME).(string)
+	datalakes, err := client.ListDatalakes(name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	matches := []adverityclient.Datalake{}
+	for _, datalake := range datalakes {
+		if datalake.Name == name {
+			matches = append(matches, datalake)
+		}
+	}
+	if len(matches) == 0 {
+		return diag.Errorf("No datalake found with name %s", name)
+	}
+	if len(matches) > 1 {
+		return diag.Errorf("Found %d datalakes with name %s, datalake names must be unique to be looked up", len(matches), name)
+	}
+	res := matches[0]
+
+	d.SetId(strconv.Itoa(res.ID))
+	d.Set(DATALAKE_ID, strconv.Itoa(res.ID))
+	d.Set(STORAGE_ID, res.Storage)
+	d.Set("description", res.Description)
+	d.Set(URL, res.URL)
+
+	return diags
+}
@@ -0,0 +1,117 @@
+package adverity
+
+import (
+	"context"
+
+	"github.com/devoteamgcloud/adverityclient"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceAdverityDestinationMappings() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			DATASTREAM_ID: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ExactlyOneOf: []string{DATASTREAM_ID, DESTINATION_ID},
+				Description:  "The ID of the datastream to list the mappings for. Searches the destinations of all destination types, so this can take a while on large instances.",
+			},
+			DESTINATION_ID: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ExactlyOneOf: []string{DATASTREAM_ID, DESTINATION_ID},
+				RequiredWith: []string{DESTINATION_ID, DESTINATION_TYPE},
+				Description:  "The ID of the destination to list the mappings for.",
+			},
+			DESTINATION_TYPE: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{DESTINATION_ID, DESTINATION_TYPE},
+				Description:  "The type ID of the destination to list the mappings for. Required with destination_id.",
+			},
+			"mappings": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the destination mapping.",
+						},
+						DESTINATION_TYPE: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The type ID of the destination.",
+						},
+						DESTINATION_ID: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the destination.",
+						},
+						DATASTREAM_ID: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the datastream.",
+						},
+						TABLE_NAME: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the table the datastream writes to.",
+						},
+						"write_mode": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "How the data of a fetch is written to the table.",
+						},
+					},
+				},
+				Description: "The destination mappings of the datastream or destination.",
+			},
+		},
+		ReadContext: datasourceDestinationMappings,
+		Description: "This data source lists the destination mappings of a datastream or of a destination. Useful for auditing which datastreams write to which tables.",
+	}
+}
+
+func datasourceDestinationMappings(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	providerConfig := m.(*config)
+	client := *providerConfig.Client
+	var mappings []adverityclient.DestinationMapping
+	var err error
+	if datastreamID, exists := d.GetOk(DATASTREAM_ID); exists {
+		mappings, err = client.ListDatastreamDestinationMappings(datastreamID.(int))
+	} else {
+		mappings, err = client.ListDestinationMappings(d.Get(DESTINATION_TYPE).(int), d.Get(DESTINATION_ID).(int), nil)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("mappings", flattenDestinationMappings(mappings)); err != nil {
+		return diag.FromErr(err)
+	}
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	d.SetId(id)
+	return diags
+}
+
+func flattenDestinationMappings(mappings []adverityclient.DestinationMapping) []interface{} {
+	flattened := make([]interface{}, len(mappings), len(mappings))
+	for i, mapping := range mappings {
+		mp := make(map[string]interface{})
+		mp["id"] = mapping.ID
+		mp[DESTINATION_TYPE] = mapping.DestinationType
+		mp[DESTINATION_ID] = mapping.Target
+		mp[DATASTREAM_ID] = mapping.Datastream
+		mp[TABLE_NAME] = mapping.TableName
+		mp["write_mode"] = mapping.WriteMode
+		flattened[i] = mp
+	}
+	return flattened
+}
@@ -0,0 +1,287 @@
+package adverity
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devoteamgcloud/adverityclient"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func datasourceAdverityJobs() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			DATASTREAM_ID: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{DATASTREAM_ID, WORKSPACE_ID},
+				Description:  "The ID of the datastream to list the jobs for.",
+			},
+			WORKSPACE_ID: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ExactlyOneOf: []string{DATASTREAM_ID, WORKSPACE_ID},
+				Description:  "The ID of the workspace to list the jobs for.",
+			},
+			"states": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "Only return jobs whose state label matches one of these values (case insensitive), for example \"Error\" or \"Finished\".",
+			},
+			"trigger": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "all",
+				ValidateFunc: validation.StringInSlice([]string{"all", "manual", "scheduled"}, false),
+				Description:  "Whether to return all jobs, only manually started jobs or only scheduled jobs.",
+			},
+			"started_after": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "Only return jobs that started at or after this time, in RFC 3339 format (e.g. 2006-01-02T15:04:05Z).",
+			},
+			"started_before": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+				Description:  "Only return jobs that started before this time, in RFC 3339 format (e.g. 2006-01-02T15:04:05Z).",
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      50,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum number of jobs to return, starting with the most recent ones.",
+			},
+			"jobs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the job.",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The API url of the job.",
+						},
+						"state": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The numeric state of the job.",
+						},
+						"state_label": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The label of the state of the job.",
+						},
+						"state_color": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The color Adverity uses to display the state of the job.",
+						},
+						"progress": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The progress of the job, as a percentage.",
+						},
+						"job_start": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the job started.",
+						},
+						"job_end": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the job ended. Empty if the job hasn't finished.",
+						},
+						"finished": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the job has finished.",
+						},
+						"manual": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the job was started manually, as opposed to by a schedule.",
+						},
+						"issues": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"message": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The message of the issue.",
+									},
+									"type": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The type of the issue.",
+									},
+									"extraction_stage": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The extraction stage in which the issue occurred.",
+									},
+									"url": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The url of the issue in Adverity.",
+									},
+								},
+							},
+							Description: "The issues reported for the job.",
+						},
+					},
+				},
+				Description: "The jobs matching the given filters, most recent first.",
+			},
+		},
+		ReadContext: datasourceJobs,
+		Description: "This data source lists the recent jobs of a datastream or of all datastreams in a workspace. Useful for building checks and outputs around failed loads.",
+	}
+}
+
+func datasourceJobs(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	limit := d.Get("limit").(int)
+	trigger := d.Get("trigger").(string)
+	filters := []adverityclient.Query{}
+	if datastreamID, exists := d.GetOk(DATASTREAM_ID); exists {
+		filters = append(filters, adverityclient.Query{
+			Key:   "datastream_id",
+			Value: datastreamID.(string),
+		})
+	}
+	if workspaceID, exists := d.GetOk(WORKSPACE_ID); exists {
+		filters = append(filters, adverityclient.Query{
+			Key:   "stack_id",
+			Value: strconv.Itoa(workspaceID.(int)),
+		})
+	}
+	states := []string{}
+	for _, state := range d.Get("states").([]interface{}) {
+		states = append(states, strings.ToLower(state.(string)))
+	}
+	// The API only filters on a single state label, multiple states are filtered below
+	serverLimit := limit
+	if len(states) == 1 {
+		filters = append(filters, adverityclient.Query{
+			Key:   "state_label",
+			Value: d.Get("states.0").(string),
+		})
+	} else if len(states) > 1 {
+		serverLimit = 0
+	}
+	if trigger != "all" {
+		filters = append(filters, adverityclient.Query{
+			Key:   "manual",
+			Value: strconv.FormatBool(trigger == "manual"),
+		})
+	}
+	var startedAfter, startedBefore time.Time
+	if after, exists := d.GetOk("started_after"); exists {
+		startedAfter, _ = time.Parse(time.RFC3339, after.(string))
+		filters = append(filters, adverityclient.Query{
+			Key:   "job_start__gte",
+			Value: startedAfter.Format(time.RFC3339),
+		})
+	}
+	if before, exists := d.GetOk("started_before"); exists {
+		startedBefore, _ = time.Parse(time.RFC3339, before.(string))
+		filters = append(filters, adverityclient.Query{
+			Key:   "job_start__lt",
+			Value: startedBefore.Format(time.RFC3339),
+		})
+	}
+
+	providerConfig := m.(*config)
+	client := *providerConfig.Client
+	results, err := client.ListJobs(filters, serverLimit)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// The filters are sent to the API, they are checked again here in case the API ignores any of them
+	jobs := []adverityclient.Job{}
+	jobStarts := map[int]time.Time{}
+	for _, job := range results {
+		// Jobs that are still queued have no start time yet, they are kept and sorted as the most recent ones
+		jobStart := time.Now()
+		if job.JobStart != "" {
+			jobStart, err = time.Parse(time.RFC3339Nano, job.JobStart)
+			if err != nil {
+				return diag.Errorf("Could not parse the start time %q of job %d: %s", job.JobStart, job.ID, err)
+			}
+		}
+		jobStarts[job.ID] = jobStart
+		if (trigger == "manual" && !job.Manual) || (trigger == "scheduled" && job.Manual) {
+			continue
+		}
+		if len(states) > 0 {
+			found := false
+			for _, state := range states {
+				if strings.ToLower(job.StateLabel) == state {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		if (!startedAfter.IsZero() && jobStart.Before(startedAfter)) || (!startedBefore.IsZero() && !jobStart.Before(startedBefore)) {
+			continue
+		}
+		jobs = append(jobs, job)
+	}
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobStarts[jobs[i].ID].After(jobStarts[jobs[j].ID])
+	})
+	if len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	if err := d.Set("jobs", flattenJobList(jobs)); err != nil {
+		return diag.FromErr(err)
+	}
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	d.SetId(id)
+	return diags
+}
+
+func flattenJobList(jobs []adverityclient.Job) []interface{} {
+	flattened := make([]interface{}, len(jobs), len(jobs))
+	for i, job := range jobs {
+		jb := make(map[string]interface{})
+		jb["id"] = job.ID
+		jb["url"] = job.URL
+		jb["state"] = job.State
+		jb["state_label"] = job.StateLabel
+		jb["state_color"] = job.StateColor
+		jb["progress"] = job.Progress
+		jb["job_start"] = job.JobStart
+		jb["job_end"] = job.JobEnd
+		jb["finished"] = job.JobEnd != ""
+		jb["manual"] = job.Manual
+		jb["issues"] = flattenIssues(job.Issues)
+		flattened[i] = jb
+	}
+	return flattened
+}
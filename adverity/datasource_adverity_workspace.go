@@ -2,6 +2,7 @@ package adverity
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -18,7 +19,7 @@ func datasourceWorkspace() *schema.Resource {
 			DATALAKE_ID: {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "The ID of the datalake this workspace is bound to.",
+				Description: "The ID of the datalake this workspace is bound to. Can be used as the datalake_id of a workspace.",
 			},
 			PARENT_ID: {
 				Type:        schema.TypeInt,
@@ -26,14 +27,18 @@ func datasourceWorkspace() *schema.Resource {
 				Description: "The Id of the parent workspace, if any.",
 			},
 			SLUG: {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The slug of this workspace.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				AtLeastOneOf: []string{SLUG, WORKSPACE_ID},
+				Description:  "The slug of this workspace. Either this or workspace_id must be given.",
 			},
 			WORKSPACE_ID: {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The ID of this workspace.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				AtLeastOneOf: []string{SLUG, WORKSPACE_ID},
+				Description:  "The ID of this workspace. Either this or slug must be given.",
 			},
 		},
 		ReadContext: workspaceDataSource,
@@ -43,14 +48,17 @@ func datasourceWorkspace() *schema.Resource {
 
 func workspaceDataSource(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
+	// The ID doesn't change when the workspace is renamed, so it is preferred over the slug
 	workspace_id := d.Get(WORKSPACE_ID).(string)
-	slug := d.Get(SLUG).(string)
+	if workspace_id == "" {
+		workspace_id = d.Get(SLUG).(string)
+	}
 
 	providerConfig := m.(*config)
 
 	client := *providerConfig.Client
 
-	res, err, code := client.ReadWorkspace(slug)
+	res, err, code := client.ReadWorkspace(workspace_id)
 	if err != nil {
 		if code == 404 {
 			d.SetId("")
@@ -58,8 +66,13 @@ func workspaceDataSource(ctx context.Context, d *schema.ResourceData, m interfac
 		}
 		return diag.FromErr(err)
 	}
-	d.SetId(workspace_id)
-	d.Set(DATALAKE_ID, res.Datalake)
+	datalakeId, err := datalakeIDFromURL(res.Datalake)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(strconv.Itoa(res.ID))
+	d.Set(WORKSPACE_ID, strconv.Itoa(res.ID))
+	d.Set(DATALAKE_ID, datalakeId)
 	d.Set(PARENT_ID, res.ParentID)
 	d.Set(NAME, res.Name)
 	d.Set(SLUG, res.Slug)
@@ -0,0 +1,182 @@
+package adverity
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/devoteamgcloud/adverityclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceAdverityWorkspaces() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"root_workspace_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "The ID of the workspace to start from. If not set, all workspaces are returned, starting from the workspaces without a (visible) parent.",
+			},
+			"workspaces": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the workspace.",
+						},
+						NAME: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the workspace.",
+						},
+						SLUG: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The slug of the workspace.",
+						},
+						PARENT_ID: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the parent workspace, 0 if there is none.",
+						},
+						"depth": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The depth of the workspace in the tree, starting at 0 for the root workspaces.",
+						},
+						"children": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeInt,
+							},
+							Description: "The IDs of the direct child workspaces.",
+						},
+						DATALAKE_ID: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the datalake this workspace is bound to.",
+						},
+						"connections": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of connections in the workspace.",
+						},
+						"datastreams": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of datastreams in the workspace.",
+						},
+						URL: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The API url of the workspace.",
+						},
+						"overview_url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The url of the overview of the workspace in Adverity.",
+						},
+						"issues_url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The url of the issues of the workspace in Adverity.",
+						},
+						"extracts_url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The url of the extracts of the workspace in Adverity.",
+						},
+					},
+				},
+				Description: "The workspaces in the tree, depth first. Every workspace is followed by its children, sorted by name.",
+			},
+		},
+		ReadContext: datasourceWorkspaces,
+		Description: "This data source lists the tree of workspaces, starting from a root workspace or from the top of the hierarchy, with their counts and urls.",
+	}
+}
+
+func datasourceWorkspaces(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	providerConfig := m.(*config)
+	client := *providerConfig.Client
+	results, err := client.ListWorkspaces()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+	workspacesByID := map[int]adverityclient.Workspace{}
+	for _, workspace := range results {
+		workspacesByID[workspace.ID] = workspace
+	}
+	children := map[int][]int{}
+	roots := []int{}
+	for _, workspace := range results {
+		if _, exists := workspacesByID[workspace.ParentID]; exists && workspace.ParentID != workspace.ID {
+			children[workspace.ParentID] = append(children[workspace.ParentID], workspace.ID)
+		} else {
+			roots = append(roots, workspace.ID)
+		}
+	}
+	if rootID, exists := d.GetOk("root_workspace_id"); exists {
+		if _, found := workspacesByID[rootID.(int)]; !found {
+			return diag.Errorf("Could not find workspace %d", rootID.(int))
+		}
+		roots = []int{rootID.(int)}
+	}
+
+	workspaces := []interface{}{}
+	visited := map[int]bool{}
+	var walk func(id int, depth int) error
+	walk = func(id int, depth int) error {
+		// Guards against a parent_id cycle, which would otherwise never end
+		if visited[id] {
+			return nil
+		}
+		visited[id] = true
+		workspace := workspacesByID[id]
+		datalakeId, err := datalakeIDFromURL(workspace.Datalake)
+		if err != nil {
+			return fmt.Errorf("workspace %d: %s", id, err)
+		}
+		ws := make(map[string]interface{})
+		ws["id"] = workspace.ID
+		ws[NAME] = workspace.Name
+		ws[SLUG] = workspace.Slug
+		ws[PARENT_ID] = workspace.ParentID
+		ws["depth"] = depth
+		ws["children"] = children[id]
+		ws[DATALAKE_ID] = datalakeId
+		ws["connections"] = workspace.Counts.Connections
+		ws["datastreams"] = workspace.Counts.Datastreams
+		ws[URL] = workspace.URL
+		ws["overview_url"] = workspace.OverviewURL
+		ws["issues_url"] = workspace.IssuesURL
+		ws["extracts_url"] = workspace.ExtractsURL
+		workspaces = append(workspaces, ws)
+		for _, child := range children[id] {
+			if err := walk(child, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, root := range roots {
+		if err := walk(root, 0); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("workspaces", workspaces); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(strconv.Itoa(d.Get("root_workspace_id").(int)))
+	return diags
+}
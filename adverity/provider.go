@@ -31,6 +31,7 @@ const (
 	TABLE_NAME            = "table_name"
 	IS_AUTHORIZED         = "is_authorized"
 	HEADERS_FORMATTING    = "headers_formatting"
+	STORAGE_ID            = "storage_id"
 )
 
 func Provider() *schema.Provider {
@@ -49,24 +50,33 @@ func Provider() *schema.Provider {
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"adverity_workspace":           workspace(),
-			"adverity_connection":          connection(),
-			"adverity_storage":             storage(),
-			"adverity_destination":         destination(),
-			"adverity_datastream":          datastream(),
-			"adverity_destination_mapping": destinationMapping(),
-			"adverity_datatype_mapping":    datatypeMapping(),
-			"adverity_fetch":               fetch(),
-			"adverity_columns":             columns(),
+			"adverity_workspace":             workspace(),
+			"adverity_connection":            connection(),
+			"adverity_storage":               storage(),
+			"adverity_destination":           destination(),
+			"adverity_datastream":            datastream(),
+			"adverity_destination_mapping":   destinationMapping(),
+			"adverity_datatype_mapping":      datatypeMapping(),
+			"adverity_fetch":                 fetch(),
+			"adverity_columns":               columns(),
+			"adverity_workspace_member":      workspaceMember(),
+			"adverity_datalake":              datalake(),
+			"adverity_datastream_enablement": datastreamEnablement(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"adverity_workspace":        datasourceWorkspace(),
-			"adverity_auth_url":         datasourceAuthUrl(),
-			"adverity_lookup":           datasourceAdverityLookup(),
-			"adverity_connection_type":  datasourceAdverityConnectionType(),
-			"adverity_datastream_type":  datasourceAdverityDatastreamType(),
-			"adverity_destination_type": datasourceAdverityDestinationType(),
-			"adverity_connection_app":   datasourceAdverityConnectionApp(),
+			"adverity_workspace":            datasourceWorkspace(),
+			"adverity_auth_url":             datasourceAuthUrl(),
+			"adverity_lookup":               datasourceAdverityLookup(),
+			"adverity_connection_type":      datasourceAdverityConnectionType(),
+			"adverity_datastream_type":      datasourceAdverityDatastreamType(),
+			"adverity_destination_type":     datasourceAdverityDestinationType(),
+			"adverity_connection_app":       datasourceAdverityConnectionApp(),
+			"adverity_jobs":                 datasourceAdverityJobs(),
+			"adverity_destination_mappings": datasourceAdverityDestinationMappings(),
+			"adverity_connection_types":     datasourceAdverityConnectionTypes(),
+			"adverity_connection_apps":      datasourceAdverityConnectionApps(),
+			"adverity_workspaces":           datasourceAdverityWorkspaces(),
+			"adverity_datalake":             datasourceAdverityDatalake(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
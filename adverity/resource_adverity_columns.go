@@ -9,6 +9,7 @@ import (
 
 	"github.com/devoteamgcloud/adverityclient"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -25,13 +26,60 @@ func columns() *schema.Resource {
 			},
 			"schema": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
+				ExactlyOneOf: []string{"schema", "column"},
 				ValidateFunc: validation.StringIsJSON,
 				StateFunc: func(v interface{}) string {
 					json, _ := structure.NormalizeJsonString(v)
 					return json
 				},
-				Description: "A JSON schema with all the required columns and their datatype.",
+				Description: "A JSON schema with all the required columns and their datatype, in the format of `bq show --schema`. Either this or column blocks must be given.",
+			},
+			"column": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				ExactlyOneOf: []string{"schema", "column"},
+				Set:          columnDefinitionHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the column.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The datatype of the column in the warehouse, for example STRING or INTEGER. Must be a datatype of the chosen type_dialect.",
+						},
+						"key": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the column is part of the key of a row.",
+						},
+						"mapped": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the column has been schema mapped (has a target column).",
+						},
+					},
+				},
+				Description: "The columns and their datatype, as an alternative to the JSON schema. Changes to a single column show up as a change to that column in the plan.",
+			},
+			"nested_fields_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      nestedFieldsJSON,
+				ValidateFunc: validation.StringInSlice([]string{nestedFieldsJSON, nestedFieldsFlatten}, false),
+				Description:  "How RECORD columns in the JSON schema are created in Adverity. 'json' creates a single JSON column, 'flatten' creates a column for every field, named parent.field. REPEATED columns are always created as JSON columns.",
+			},
+			"type_dialect": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "bigquery",
+				ValidateFunc: validation.StringInSlice(typeDialectNames(), false),
+				Description:  "The warehouse the datatypes in the schema or column blocks belong to. One of bigquery, postgres, redshift or snowflake.",
 			},
 			"ignored_columns": {
 				Type: schema.TypeList,
@@ -41,7 +89,63 @@ func columns() *schema.Resource {
 				Optional:    true,
 				Description: "A list of columns which may be present in the schema JSON, but that will be ignored when setting columns in Adverity.",
 			},
+			"key_columns": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:      true,
+				ConflictsWith: []string{"column"},
+				Description:   "The names of the columns that make up the key of a row. Used by the overwrite_key_columns setting of the datastream. When using column blocks, set key in the block instead.",
+			},
+			"removed_columns": {
+				Type: schema.TypeSet,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Optional:    true,
+				Description: "The names of the columns that should be kept in Adverity, but marked as removed so they are no longer loaded into the destination.",
+			},
+			"target_column": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"column": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the column in the schema this mapping applies to.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the target column the column is mapped to.",
+						},
+						"usage": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The usage of the target column, for example a dimension or a metric.",
+						},
+						"measure": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The measure of the target column.",
+						},
+					},
+				},
+				Description: "Target column mappings for columns in the schema. Only the target columns of the columns listed here are managed, unless manage_all_target_columns is set. Removing a mapping from here removes the target column in Adverity.",
+			},
+			"manage_all_target_columns": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If set to true, all target columns of the datastream are managed by this resource, so mappings made in Adverity itself are removed unless they are configured in target_column as well.",
+			},
 		},
+		CustomizeDiff: customdiff.All(
+			validateColumnTypes,
+			validateRemovedColumns,
+		),
 		CreateContext: columnsCreate,
 		ReadContext:   columnsRead,
 		DeleteContext: columnsDelete,
@@ -52,45 +156,18 @@ func columns() *schema.Resource {
 
 func columnsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	typeMapping := map[string]string{
-		"STRING":   "String",
-		"INTEGER":  "Long",
-		"FLOAT":    "Float",
-		"DATE":     "Date",
-		"DATETIME": "DateTime",
-		"BOOLEAN":  "Boolean",
-		"JSON":     "JSON",
-	}
 	datastreamID := d.Get("datastream_id").(string)
 	providerConfig := m.(*config)
 	client := *providerConfig.Client
-	var definedSchema []adverityclient.SchemaElementNoMode
-	schemaText := d.Get("schema").(string)
-	if err := json.Unmarshal([]byte(schemaText), &definedSchema); err != nil {
+	columnConfigs, err := expandColumnConfigs(d)
+	if err != nil {
 		return diag.FromErr(err)
 	}
-	var columnConfigs []adverityclient.ColumnConfig
-	ignoredColumns := []string{}
-	if _, exists := d.GetOk("ignored_columns"); exists {
-		for _, column := range d.Get("ignored_columns").([]interface{}) {
-			ignoredColumns = append(ignoredColumns, column.(string))
-		}
-	}
-	for _, column := range definedSchema {
-		toIgnore := false
-		for _, ignored_column := range ignoredColumns {
-			if ignored_column == column.Name {
-				toIgnore = true
-				break
-			}
-		}
-		if !toIgnore {
-			columnConfigs = append(columnConfigs, adverityclient.ColumnConfig{
-				Name: column.Name,
-				Type: typeMapping[column.Type],
-			})
-		}
+	definedSchema, _, err := definedColumnDefinitions(d)
+	if err != nil {
+		return diag.FromErr(err)
 	}
+	diags = append(diags, relaxedModeWarnings(definedSchema)...)
 	createdColumns, err := client.CreateColumns(datastreamID, columnConfigs)
 	if err != nil {
 		return diag.FromErr(err)
@@ -113,14 +190,9 @@ func columnsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) d
 
 func columnsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	typeMapping := map[string]string{
-		"String":   "STRING",
-		"Long":     "INTEGER",
-		"Float":    "FLOAT",
-		"Date":     "DATE",
-		"DateTime": "DATETIME",
-		"Boolean":  "BOOLEAN",
-		"JSON":     "JSON",
+	dialect, err := lookupTypeDialect(d.Get("type_dialect").(string))
+	if err != nil {
+		return diag.FromErr(err)
 	}
 	datastreamID := d.Get("datastream_id").(string)
 	providerConfig := m.(*config)
@@ -138,9 +210,8 @@ func columnsRead(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	var definedSchema []adverityclient.SchemaElementNoMode
-	schemaText := d.Get("schema").(string)
-	if err := json.Unmarshal([]byte(schemaText), &definedSchema); err != nil {
+	definedSchema, usesBlocks, err := definedColumnDefinitions(d)
+	if err != nil {
 		return diag.FromErr(err)
 	}
 	ignoredColumns := []string{}
@@ -149,7 +220,20 @@ func columnsRead(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 			ignoredColumns = append(ignoredColumns, column.(string))
 		}
 	}
-	var APISchema []adverityclient.SchemaElementNoMode
+	if err := setColumnSettings(d, columns, usesBlocks); err != nil {
+		return diag.FromErr(err)
+	}
+	if !usesBlocks {
+		var definedElements []adverityclient.SchemaElementMode
+		if err := json.Unmarshal([]byte(d.Get("schema").(string)), &definedElements); err != nil {
+			return diag.FromErr(err)
+		}
+		readElements := readSchemaElements(definedElements, columns, d.Get("nested_fields_strategy").(string), ".", dialect, ignoredColumns)
+		bytes, _ := json.Marshal(readElements)
+		d.Set("schema", string(bytes[:]))
+		return diags
+	}
+	var APISchema []columnDefinition
 	// For every column defined in the input schema
 	for _, definedColumn := range definedSchema {
 		found := false
@@ -159,9 +243,10 @@ func columnsRead(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 			if definedColumn.Name == column.Name {
 				// Add the column read from the API to the read schema
 				// If the column  has been schema mapped (has a target column), add mapped = true
-				APISchema = append(APISchema, adverityclient.SchemaElementNoMode{
-					Type:   typeMapping[column.DataType],
+				APISchema = append(APISchema, columnDefinition{
+					Type:   dialect.fromAdverityType(column.DataType, definedColumn.Type),
 					Name:   column.Name,
+					Key:    column.IsKeyColumn,
 					Mapped: column.TargetColumn != nil,
 				})
 				// Remove that column from the list of columns read from the API
@@ -189,14 +274,16 @@ func columnsRead(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 	for _, column := range columns {
 		// Add it to the read schema
 		// If the column  has been schema mapped (has a target column), add mapped = true
-		APISchema = append(APISchema, adverityclient.SchemaElementNoMode{
-			Type:   typeMapping[column.DataType],
+		APISchema = append(APISchema, columnDefinition{
+			Type:   dialect.fromAdverityType(column.DataType, ""),
 			Name:   column.Name,
+			Key:    column.IsKeyColumn,
 			Mapped: column.TargetColumn != nil,
 		})
 	}
-	bytes, _ := json.Marshal(APISchema)
-	d.Set("schema", string(bytes[:]))
+	if err := d.Set("column", flattenColumnDefinitions(APISchema)); err != nil {
+		return diag.FromErr(err)
+	}
 	return diags
 }
 
@@ -222,30 +309,79 @@ func columnsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) d
 
 func columnsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	typeMapping := map[string]string{
-		"STRING":   "String",
-		"INTEGER":  "Long",
-		"FLOAT":    "Float",
-		"DATE":     "Date",
-		"DATETIME": "DateTime",
-		"BOOLEAN":  "Boolean",
-		"JSON":     "JSON",
-	}
 	datastreamID := d.Get("datastream_id").(string)
 	providerConfig := m.(*config)
 	client := *providerConfig.Client
-	var definedSchema []adverityclient.SchemaElementNoMode
-	schemaText := d.Get("schema").(string)
-	if err := json.Unmarshal([]byte(schemaText), &definedSchema); err != nil {
+	columnConfigs, err := expandColumnConfigs(d)
+	if err != nil {
 		return diag.FromErr(err)
 	}
-	var columnConfigs []adverityclient.ColumnConfig
+	definedSchema, _, err := definedColumnDefinitions(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	diags = append(diags, relaxedModeWarnings(definedSchema)...)
+	createdColumns, err := client.CreateColumns(datastreamID, columnConfigs)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for _, column := range createdColumns {
+		if !column.ConfirmedType {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("After updating the columns, %s has confirmedType set to false. This should not happen and is either a bug in the API or the provider.", column.Name),
+			})
+		}
+	}
+	if diags.HasError() {
+		return diags
+	}
+	diags = append(diags, columnsRead(ctx, d, m)...)
+	return diags
+}
+
+// expandColumnConfigs builds the column configurations to send to Adverity from the schema and the per-column settings of the resource.
+func expandColumnConfigs(d *schema.ResourceData) ([]adverityclient.ColumnConfig, error) {
+	dialect, err := lookupTypeDialect(d.Get("type_dialect").(string))
+	if err != nil {
+		return nil, err
+	}
+	definedSchema, _, err := definedColumnDefinitions(d)
+	if err != nil {
+		return nil, err
+	}
 	ignoredColumns := []string{}
 	if _, exists := d.GetOk("ignored_columns"); exists {
 		for _, column := range d.Get("ignored_columns").([]interface{}) {
 			ignoredColumns = append(ignoredColumns, column.(string))
 		}
 	}
+	keyColumns := d.Get("key_columns").(*schema.Set)
+	removedColumns := d.Get("removed_columns").(*schema.Set)
+	targetColumns := map[string]*adverityclient.TargetColumn{}
+	for _, targetColumn := range d.Get("target_column").(*schema.Set).List() {
+		t := targetColumn.(map[string]interface{})
+		targetColumns[t["column"].(string)] = &adverityclient.TargetColumn{
+			Name:    t["name"].(string),
+			Usage:   t["usage"].(string),
+			Measure: t["measure"].(string),
+		}
+	}
+	// Target columns that were configured before, but no longer are, have to be removed explicitly. When manage_all_target_columns
+	// is turned off, the state still holds the target columns that were only managed because of it, those are left alone.
+	removedTargets := map[string]bool{}
+	oldTargetColumns, _ := d.GetChange("target_column")
+	oldManageAll, newManageAll := d.GetChange("manage_all_target_columns")
+	if !oldManageAll.(bool) || newManageAll.(bool) {
+		for _, targetColumn := range oldTargetColumns.(*schema.Set).List() {
+			column := targetColumn.(map[string]interface{})["column"].(string)
+			if _, exists := targetColumns[column]; !exists {
+				removedTargets[column] = true
+			}
+		}
+	}
+	var columnConfigs []adverityclient.ColumnConfig
+	definedColumns := map[string]bool{}
 	for _, column := range definedSchema {
 		toIgnore := false
 		for _, ignored_column := range ignoredColumns {
@@ -255,27 +391,189 @@ func columnsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) d
 			}
 		}
 		if !toIgnore {
-			columnConfigs = append(columnConfigs, adverityclient.ColumnConfig{
-				Name: column.Name,
-				Type: typeMapping[column.Type],
+			definedColumns[column.Name] = true
+			adverityType, err := column.adverityType(dialect)
+			if err != nil {
+				return nil, err
+			}
+			columnConfig := adverityclient.ColumnConfig{
+				Name:        column.Name,
+				Type:        adverityType,
+				IsKeyColumn: column.Key || keyColumns.Contains(column.Name),
+				Removed:     removedColumns.Contains(column.Name),
+			}
+			if targetColumn, exists := targetColumns[column.Name]; exists {
+				targetColumn.Datatype = columnConfig.Type
+				columnConfig.TargetColumn = targetColumn
+			} else if removedTargets[column.Name] {
+				columnConfig.ClearTargetColumn = true
+			}
+			columnConfigs = append(columnConfigs, columnConfig)
+		}
+	}
+	for _, setting := range []string{"key_columns", "removed_columns"} {
+		for _, column := range d.Get(setting).(*schema.Set).List() {
+			if !definedColumns[column.(string)] {
+				return nil, fmt.Errorf("column %q in %s is not defined in the schema or is ignored", column.(string), setting)
+			}
+		}
+	}
+	for column := range targetColumns {
+		if !definedColumns[column] {
+			return nil, fmt.Errorf("column %q in target_column is not defined in the schema or is ignored", column)
+		}
+	}
+	if err := validateRequiredColumns(definedSchema, "ignored_columns", ignoredColumns); err != nil {
+		return nil, err
+	}
+	removed := []string{}
+	for _, column := range removedColumns.List() {
+		removed = append(removed, column.(string))
+	}
+	if err := validateRequiredColumns(definedSchema, "removed_columns", removed); err != nil {
+		return nil, err
+	}
+	return columnConfigs, nil
+}
+
+// setColumnSettings reads back the per-column settings from the columns in Adverity. Target columns are only read back for
+// the columns that already have one in the state, so mappings made in Adverity for other columns are left alone, unless
+// manage_all_target_columns is set.
+func setColumnSettings(d *schema.ResourceData, columns []adverityclient.Column, usesBlocks bool) error {
+	manageAll := d.Get("manage_all_target_columns").(bool)
+	managedTargets := map[string]bool{}
+	for _, targetColumn := range d.Get("target_column").(*schema.Set).List() {
+		managedTargets[targetColumn.(map[string]interface{})["column"].(string)] = true
+	}
+	keyColumns := []interface{}{}
+	removedColumns := []interface{}{}
+	targetColumns := []interface{}{}
+	for _, column := range columns {
+		// When using column blocks, the key is read back as part of the column block
+		if column.IsKeyColumn && !usesBlocks {
+			keyColumns = append(keyColumns, column.Name)
+		}
+		if column.Removed {
+			removedColumns = append(removedColumns, column.Name)
+		}
+		if column.TargetColumn != nil && (manageAll || managedTargets[column.Name]) {
+			targetColumns = append(targetColumns, map[string]interface{}{
+				"column":  column.Name,
+				"name":    column.TargetColumn.Name,
+				"usage":   column.TargetColumn.Usage,
+				"measure": column.TargetColumn.Measure,
 			})
 		}
 	}
-	createdColumns, err := client.CreateColumns(datastreamID, columnConfigs)
+	if err := d.Set("key_columns", keyColumns); err != nil {
+		return err
+	}
+	if err := d.Set("removed_columns", removedColumns); err != nil {
+		return err
+	}
+	return d.Set("target_column", targetColumns)
+}
+
+// columnDefinition is a single column as defined in either the JSON schema or a column block.
+type columnDefinition struct {
+	Name   string
+	Type   string
+	Mode   string
+	Key    bool
+	Mapped bool
+	// AdverityType is set for nested columns, which are stored in Adverity as JSON regardless of the type dialect
+	AdverityType string
+	// RequiredFields are the names of the REQUIRED fields nested inside a column stored as JSON
+	RequiredFields []string
+	// RelaxedMode is set for REQUIRED fields that were made NULLABLE, because they were flattened out of a record that isn't REQUIRED
+	RelaxedMode bool
+}
+
+func (c columnDefinition) adverityType(dialect *typeDialect) (string, error) {
+	if c.AdverityType != "" {
+		return c.AdverityType, nil
+	}
+	adverityType, err := dialect.toAdverityType(c.Type)
 	if err != nil {
-		return diag.FromErr(err)
+		return "", fmt.Errorf("column %q: %s", c.Name, err)
 	}
-	for _, column := range createdColumns {
-		if !column.ConfirmedType {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  fmt.Sprintf("After updating the columns, %s has confirmedType set to false. This should not happen and is either a bug in the API or the provider.", column.Name),
-			})
+	return adverityType, nil
+}
+
+// definedColumnDefinitions returns the columns defined in the resource, and whether they were defined with column blocks
+// instead of the JSON schema.
+func definedColumnDefinitions(d *schema.ResourceData) ([]columnDefinition, bool, error) {
+	definitions := []columnDefinition{}
+	schemaText := d.Get("schema").(string)
+	if schemaText == "" {
+		for _, column := range d.Get("column").(*schema.Set).List() {
+			c := column.(map[string]interface{})
+			definition := columnDefinition{
+				Name: c["name"].(string),
+				Type: c["type"].(string),
+			}
+			if key, exists := c["key"]; exists {
+				definition.Key = key.(bool)
+			}
+			definitions = append(definitions, definition)
 		}
+		return definitions, true, nil
 	}
-	if diags.HasError() {
-		return diags
+	var definedSchema []adverityclient.SchemaElementMode
+	if err := json.Unmarshal([]byte(schemaText), &definedSchema); err != nil {
+		return nil, false, err
 	}
-	diags = append(diags, columnsRead(ctx, d, m)...)
-	return diags
+	definitions, err := expandSchemaElements(definedSchema, d.Get("nested_fields_strategy").(string), ".")
+	if err != nil {
+		return nil, false, err
+	}
+	return definitions, false, nil
+}
+
+func flattenColumnDefinitions(definitions []columnDefinition) []interface{} {
+	columns := make([]interface{}, len(definitions), len(definitions))
+	for i, definition := range definitions {
+		column := make(map[string]interface{})
+		column["name"] = definition.Name
+		column["type"] = definition.Type
+		column["key"] = definition.Key
+		column["mapped"] = definition.Mapped
+		columns[i] = column
+	}
+	return columns
+}
+
+// columnDefinitionHash only hashes the configurable fields of a column block, so that the computed mapped field doesn't cause
+// a column to show up as changed.
+func columnDefinitionHash(v interface{}) int {
+	c := v.(map[string]interface{})
+	key := false
+	if k, exists := c["key"]; exists && k != nil {
+		key = k.(bool)
+	}
+	return schema.HashString(fmt.Sprintf("%s-%s-%t", c["name"].(string), c["type"].(string), key))
+}
+
+// validateRemovedColumns is a CustomizeDiff function that makes sure columns with mode REQUIRED are not marked as removed.
+func validateRemovedColumns(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("schema") || !d.NewValueKnown("removed_columns") {
+		return nil
+	}
+	schemaText := d.Get("schema").(string)
+	if schemaText == "" {
+		return nil
+	}
+	var definedSchema []adverityclient.SchemaElementMode
+	if err := json.Unmarshal([]byte(schemaText), &definedSchema); err != nil {
+		return err
+	}
+	definitions, err := expandSchemaElements(definedSchema, d.Get("nested_fields_strategy").(string), ".")
+	if err != nil {
+		return err
+	}
+	removed := []string{}
+	for _, column := range d.Get("removed_columns").(*schema.Set).List() {
+		removed = append(removed, column.(string))
+	}
+	return validateRequiredColumns(definitions, "removed_columns", removed)
 }
@@ -2,13 +2,18 @@ package adverity
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"log"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/devoteamgcloud/adverityclient"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func connection() *schema.Resource {
@@ -43,7 +48,35 @@ func connection() *schema.Resource {
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
-				Description: "A map of extra parameters needed for connection creation. For example 'app' or 'service_account_data'.",
+				Description: "A map of extra parameters needed for connection creation. For example 'app'. Use sensitive_parameters for credentials.",
+			},
+			"sensitive_parameters": {
+				Type:      schema.TypeMap,
+				Optional:  true,
+				Sensitive: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				DiffSuppressFunc: suppressSensitiveParameterDiff,
+				Description:      "A map of extra parameters that contain credentials, for example 'service_account_data'. These are masked in plans, and only a SHA-256 hash of every value is kept in the state to detect changes. The values can't be read back from Adverity.",
+			},
+			"rotation_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "An arbitrary value that, when changed, sends all sensitive_parameters to Adverity again without recreating the connection. Useful for rotating credentials that have been changed outside of Terraform.",
+			},
+			"wait_for_authorisation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If set to true, Terraform will wait after creating the connection until it has been authorised, up to the create timeout. If the connection isn't authorised in time, it is still created and a warning with the url to authorise it is shown.",
+			},
+			"authorisation_poll_interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      15,
+				ValidateFunc: validation.IntAtLeast(5),
+				Description:  "The number of seconds between checks whether the connection has been authorised, when wait_for_authorisation is set.",
 			},
 			IS_AUTHORIZED: {
 				Type:        schema.TypeBool,
@@ -52,6 +85,9 @@ func connection() *schema.Resource {
 			},
 		},
 		Description: "This resource will create a connection (a.k.a. authorization) of the given type in the given workspace.",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
 	}
 }
 
@@ -60,18 +96,7 @@ func connectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}
 	stack := d.Get(STACK).(int)
 	connectionTypeId := d.Get(CONNECTION_TYPE_ID).(int)
 
-	connectionParameters, exists := d.GetOk(CONNECTION_PARAMETERS)
-
-	parameters := []*adverityclient.Parameters{}
-
-	if exists {
-		for n, v := range connectionParameters.(map[string]interface{}) {
-			parameter := new(adverityclient.Parameters)
-			parameter.Value = v.(string)
-			parameter.Name = n
-			parameters = append(parameters, parameter)
-		}
-	}
+	parameters := expandConnectionParameters(d, false)
 
 	providerConfig := m.(*config)
 
@@ -90,8 +115,15 @@ func connectionCreate(ctx context.Context, d *schema.ResourceData, m interface{}
 	}
 
 	d.SetId(strconv.Itoa(res.ID))
+	if err := d.Set("sensitive_parameters", hashSensitiveParameters(d)); err != nil {
+		return diag.FromErr(err)
+	}
 
-	return connectionRead(ctx, d, m)
+	diags := connectionRead(ctx, d, m)
+	if diags.HasError() || !d.Get("wait_for_authorisation").(bool) || d.Get(IS_AUTHORIZED).(bool) {
+		return diags
+	}
+	return append(diags, connectionWaitForAuthorisation(ctx, d, m)...)
 }
 
 func connectionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -123,17 +155,8 @@ func connectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 	stack := d.Get(STACK).(int)
 	connectionTypeId := d.Get(CONNECTION_TYPE_ID).(int)
 
-	connectionParameters, exists := d.GetOk(CONNECTION_PARAMETERS)
-
-	parameters := []*adverityclient.Parameters{}
-	if exists {
-		for n, v := range connectionParameters.(map[string]interface{}) {
-			parameter := new(adverityclient.Parameters)
-			parameter.Value = v.(string)
-			parameter.Name = n
-			parameters = append(parameters, parameter)
-		}
-	}
+	// Only the sensitive parameters that changed are known here, the others are hashes of the values sent before
+	parameters := expandConnectionParameters(d, true)
 
 	providerConfig := m.(*config)
 
@@ -150,6 +173,9 @@ func connectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if err := d.Set("sensitive_parameters", hashSensitiveParameters(d)); err != nil {
+		return diag.FromErr(err)
+	}
 	return connectionRead(ctx, d, m)
 }
 
@@ -183,3 +209,114 @@ func connectionImportHelper(ctx context.Context, d *schema.ResourceData, m inter
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// expandConnectionParameters returns the connection parameters together with the sensitive parameters. If onlyChanged is
+// set, only the sensitive parameters that changed since the last apply are included, since the state only holds hashes.
+func expandConnectionParameters(d *schema.ResourceData, onlyChanged bool) []*adverityclient.Parameters {
+	parameters := []*adverityclient.Parameters{}
+	if connectionParameters, exists := d.GetOk(CONNECTION_PARAMETERS); exists {
+		for n, v := range connectionParameters.(map[string]interface{}) {
+			parameter := new(adverityclient.Parameters)
+			parameter.Value = v.(string)
+			parameter.Name = n
+			parameters = append(parameters, parameter)
+		}
+	}
+	oldValue, newValue := d.GetChange("sensitive_parameters")
+	oldParameters := oldValue.(map[string]interface{})
+	for n, v := range newValue.(map[string]interface{}) {
+		if onlyChanged && oldParameters[n] == v {
+			continue
+		}
+		parameter := new(adverityclient.Parameters)
+		parameter.Value = v.(string)
+		parameter.Name = n
+		parameters = append(parameters, parameter)
+	}
+	return parameters
+}
+
+func hashSensitiveParameter(value string) string {
+	hash := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(hash[:])
+}
+
+// hashSensitiveParameters returns the sensitive parameters with every value replaced by its hash, so the values themselves
+// are not kept in the state. Values that are already hashes are kept as they are.
+func hashSensitiveParameters(d *schema.ResourceData) map[string]interface{} {
+	oldValue, newValue := d.GetChange("sensitive_parameters")
+	oldParameters := oldValue.(map[string]interface{})
+	hashed := map[string]interface{}{}
+	for n, v := range newValue.(map[string]interface{}) {
+		if oldParameters[n] == v {
+			hashed[n] = v
+		} else {
+			hashed[n] = hashSensitiveParameter(v.(string))
+		}
+	}
+	return hashed
+}
+
+// suppressSensitiveParameterDiff compares the configured value of a sensitive parameter with the hash in the state. The diff
+// is never suppressed when the rotation_trigger changes, so all sensitive parameters are sent again.
+func suppressSensitiveParameterDiff(k, old, new string, d *schema.ResourceData) bool {
+	if strings.HasSuffix(k, ".%") || d.HasChange("rotation_trigger") {
+		return false
+	}
+	return old != "" && old == hashSensitiveParameter(new)
+}
+
+// connectionWaitForAuthorisation polls the connection until it has been authorised or the create timeout has passed. Running
+// out of time or failing to check is not an error, since the connection itself was created and can still be authorised later.
+func connectionWaitForAuthorisation(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	connectionTypeId := d.Get(CONNECTION_TYPE_ID).(int)
+	interval := time.Duration(d.Get("authorisation_poll_interval").(int)) * time.Second
+	// Stop waiting a bit before the create timeout cancels the context, so the connection is kept instead of being tainted
+	deadline := time.Now().Add(d.Timeout(schema.TimeoutCreate))
+	if ctxDeadline, exists := ctx.Deadline(); exists {
+		deadline = ctxDeadline.Add(-30 * time.Second)
+	}
+
+	providerConfig := m.(*config)
+
+	client := *providerConfig.Client
+
+	// The url changes every time it is requested, so it is only requested once
+	authUrl, err := client.ReadAuthUrl(strconv.Itoa(connectionTypeId), d.Id())
+	if err != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Could not wait for connection %s to be authorised", d.Id()),
+			Detail:   fmt.Sprintf("The connection was created, but the url to authorise it could not be read: %s", err),
+		})
+	}
+	notAuthorised := diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Connection %s was not authorised in time", d.Id()),
+		Detail:   fmt.Sprintf("Authorise the connection by following this url: %s", authUrl.URL),
+	}
+	log.Printf("[WARN] Waiting for connection %s to be authorised. Authorise it by following this url: %s", d.Id(), authUrl.URL)
+	for time.Now().Before(deadline) {
+		wait := interval
+		if remaining := time.Until(deadline); remaining < wait {
+			wait = remaining
+		}
+		select {
+		case <-ctx.Done():
+			return append(diags, notAuthorised)
+		case <-time.After(wait):
+		}
+		res, err, _ := client.ReadConnection(d.Id(), connectionTypeId)
+		if err != nil {
+			notAuthorised.Summary = fmt.Sprintf("Could not check whether connection %s has been authorised", d.Id())
+			notAuthorised.Detail = fmt.Sprintf("%s. %s", err, notAuthorised.Detail)
+			return append(diags, notAuthorised)
+		}
+		if res.IsAuthorized {
+			d.Set(IS_AUTHORIZED, true)
+			return diags
+		}
+	}
+	return append(diags, notAuthorised)
+}
@@ -0,0 +1,134 @@
+package adverity
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/devoteamgcloud/adverityclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datalake() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: datalakeCreate,
+		ReadContext:   datalakeRead,
+		UpdateContext: datalakeUpdate,
+		DeleteContext: datalakeDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: datalakeImportHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			NAME: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the datalake.",
+			},
+			STORAGE_ID: {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "The ID of the storage the datalake stores its data in.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the datalake.",
+			},
+			URL: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The API url of the datalake.",
+			},
+		},
+		Description: "A resource creating a datalake, binding a storage so it can be used by workspaces. Use the ID of this resource as the datalake_id of a workspace.",
+	}
+}
+
+func datalakeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConfig := m.(*config)
+
+	client := *providerConfig.Client
+
+	conf := adverityclient.DatalakeConfig{
+		Name:        d.Get(NAME).(string),
+		Description: d.Get("description").(string),
+		Storage:     d.Get(STORAGE_ID).(int),
+	}
+
+	res, err := client.CreateDatalake(conf)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(res.ID))
+
+	return datalakeRead(ctx, d, m)
+}
+
+func datalakeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	providerConfig := m.(*config)
+
+	client := *providerConfig.Client
+
+	res, err, code := client.ReadDatalake(d.Id())
+	if err != nil {
+		if code == 404 {
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+	d.Set(NAME, res.Name)
+	d.Set(STORAGE_ID, res.Storage)
+	d.Set("description", res.Description)
+	d.Set(URL, res.URL)
+
+	return diags
+}
+
+func datalakeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	providerConfig := m.(*config)
+
+	client := *providerConfig.Client
+
+	conf := adverityclient.DatalakeConfig{
+		Name:        d.Get(NAME).(string),
+		Description: d.Get("description").(string),
+		Storage:     d.Get(STORAGE_ID).(int),
+	}
+
+	_, err := client.UpdateDatalake(conf, d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return datalakeRead(ctx, d, m)
+}
+
+func datalakeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	providerConfig := m.(*config)
+
+	client := *providerConfig.Client
+
+	_, err := client.DeleteDatalake(d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func datalakeImportHelper(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if _, err := strconv.Atoi(d.Id()); err != nil {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected the numeric datalake_id", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
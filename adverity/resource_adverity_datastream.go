@@ -3,14 +3,17 @@ package adverity
 import (
 	"context"
 	"fmt"
+	"log"
 	"math/rand"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/devoteamgcloud/adverityclient"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -45,17 +48,14 @@ func datastream() *schema.Resource {
 				Description: "The description of the datastream. Must be under 1000 characters.",
 			},
 			"retention_type": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				Default:  1,
-				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
-					r_type := val.(int)
-					if r_type < 1 || r_type > 4 {
-						errs = append(errs, fmt.Errorf("%q must be an integer between 1 and 4, got %d", key, r_type))
-					}
-					return
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "all",
+				ValidateFunc: validation.StringInSlice(retentionTypeValues(), false),
+				StateFunc: func(v interface{}) string {
+					return retentionTypeName(v.(string))
 				},
-				Description: "Retention Type options: 1: Retain All, 2: Retain N fetches, 3: Retain N days, 4: Retain N extracts",
+				Description: "Retention Type options: all: Retain All, fetches: Retain N fetches, days: Retain N days, extracts: Retain N extracts. This attribute used to be a number. The numbers 1 to 4 are still accepted and stored as the matching name, so existing configurations keep working.",
 			},
 			"retention_number": {
 				Type:     schema.TypeInt,
@@ -67,7 +67,7 @@ func datastream() *schema.Resource {
 					}
 					return
 				},
-				Description: "The amount (N) of fetches/extracts/days to retain (raw extracts are not counted). Must be an integer greater than zero.",
+				Description: "The amount (N) of fetches/extracts/days to retain (raw extracts are not counted). Required for the retention types fetches, days and extracts, where it must be an integer greater than zero. Ignored for retention type all, which gives a warning.",
 			},
 			"overwrite_key_columns": {
 				Type:        schema.TypeBool,
@@ -117,14 +117,19 @@ func datastream() *schema.Resource {
 				Description: "The ID of the connection/authorization this datastream uses.",
 			},
 			"datatype": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Either 'Live' or 'Staging'.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(datastreamDatatypes, true),
+				StateFunc: func(v interface{}) string {
+					return datastreamDatatype(v.(string))
+				},
+				Description: "Either 'Live' or 'Staging'. The lower case names live and staging are accepted as well.",
 			},
 			"enabled": {
 				Type:        schema.TypeBool,
-				Required:    true,
-				Description: "Whether the datastream should be enabled or not.",
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the datastream should be enabled or not. When left unset, the datastream is created disabled, so it can be enabled by an adverity_datastream_enablement resource once its destination mapping and columns are in place.",
 			},
 			"datastream_type_id": {
 				Type:        schema.TypeInt,
@@ -249,7 +254,24 @@ func datastream() *schema.Resource {
 				},
 				Description: "A configuration to randomise the time of day for when fetches should be scheduled.",
 			},
+			"on_create_failure": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "taint",
+				ValidateFunc: validation.StringInSlice([]string{"taint", "rollback"}, false),
+				Description:  "What to do with the datastream when it was created, but a step after creating it failed. With taint, it is kept and saved as tainted, so it is replaced on the next apply. With rollback, it is deleted again.",
+			},
+			"allow_destructive_changes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Changes that can delete data already loaded into the destination, like turning on overwrite_datastream, limiting the retention when all data was kept, switching to another limited retention_type or lowering the retention_number, are refused unless this is set to true. The plan error explains what would be deleted. When this is set to true, that is only shown as a warning after applying.",
+			},
 		},
+		CustomizeDiff: customdiff.All(
+			validateRetention,
+			validateDestructiveChanges,
+		),
 		Description: "This resource will create a datastream of the given type in the given workspace.",
 	}
 }
@@ -258,7 +280,7 @@ func datastreamCreate(ctx context.Context, d *schema.ResourceData, m interface{}
 	name := d.Get("name").(string)
 	stack := d.Get("stack").(int)
 	auth := d.Get("auth").(int)
-	datatype := d.Get("datatype").(string)
+	datatype := datastreamDatatype(d.Get("datatype").(string))
 	datastream_type_id := d.Get("datastream_type_id").(int)
 	enabled := d.Get("enabled").(bool)
 
@@ -366,7 +388,7 @@ func datastreamCreate(ctx context.Context, d *schema.ResourceData, m interface{}
 		conf.Description = &desc
 	}
 	if retention_type, exists := d.GetOkExists("retention_type"); exists {
-		ret_type := retention_type.(int)
+		ret_type := retentionTypeNumbers[retentionTypeName(retention_type.(string))]
 		conf.RetentionType = &ret_type
 	}
 	if retention_number, exists := d.GetOkExists("retention_number"); exists {
@@ -414,10 +436,43 @@ func datastreamCreate(ctx context.Context, d *schema.ResourceData, m interface{}
 	_, enablingErr := client.EnableDatastream(enabledConf, d.Id())
 
 	if enablingErr != nil {
-		return diag.FromErr(err)
+		return datastreamCreateFailed(d, m, enablingErr)
 	}
 
-	return datastreamRead(ctx, d, m)
+	return append(retentionWarnings(d), datastreamRead(ctx, d, m)...)
+}
+
+// datastreamCreateFailed handles a failure after the datastream was created. The datastream is either kept, in which case
+// Terraform saves it as tainted because its ID is set, or deleted again, depending on on_create_failure.
+func datastreamCreateFailed(d *schema.ResourceData, m interface{}, err error) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if d.Get("on_create_failure").(string) != "rollback" {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  err.Error(),
+			Detail:   fmt.Sprintf("Datastream %s was created, but could not be set up completely. It is saved as tainted and will be replaced on the next apply.", d.Id()),
+		})
+	}
+
+	providerConfig := m.(*config)
+
+	client := *providerConfig.Client
+
+	_, deleteErr := client.DeleteDatastream(d.Id(), d.Get("datastream_type_id").(int))
+	if deleteErr != nil {
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  err.Error(),
+			Detail:   fmt.Sprintf("Datastream %s was created, but could not be set up completely. Rolling it back failed as well, so it is saved as tainted: %s", d.Id(), deleteErr),
+		})
+	}
+	log.Printf("[WARN] Rolled back datastream %s after its creation failed", d.Id())
+	d.SetId("")
+	return append(diags, diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  err.Error(),
+		Detail:   "The datastream was created, but could not be set up completely, so it was deleted again.",
+	})
 }
 
 func datastreamRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -446,7 +501,7 @@ func datastreamRead(ctx context.Context, d *schema.ResourceData, m interface{})
 	d.Set("enabled", res.Enabled)
 	d.Set("auth", res.Auth)
 	d.Set("datatype", res.Datatype)
-	d.Set("retention_type", res.RetentionType)
+	d.Set("retention_type", retentionTypeName(strconv.Itoa(res.RetentionType)))
 	d.Set("retention_number", res.RetentionNumber)
 	d.Set("overwrite_key_columns", res.OverwriteKeyColumns)
 	d.Set("overwrite_datastream", res.OverwriteDatastream)
@@ -459,6 +514,7 @@ func datastreamRead(ctx context.Context, d *schema.ResourceData, m interface{})
 }
 
 func datastreamUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
 	providerConfig := m.(*config)
 	client := *providerConfig.Client
 
@@ -480,7 +536,7 @@ func datastreamUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 		common_conf.Description = &desc
 	}
 	if retention_type, exists := d.GetOkExists("retention_type"); exists {
-		ret_type := retention_type.(int)
+		ret_type := retentionTypeNumbers[retentionTypeName(retention_type.(string))]
 		common_conf.RetentionType = &ret_type
 	}
 	if retention_number, exists := d.GetOkExists("retention_number"); exists {
@@ -534,23 +590,34 @@ func datastreamUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if changes := destructiveDatastreamChanges(d, func(string) bool { return true }); len(changes) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Applied changes to datastream %s that can delete data in the destination", d.Id()),
+			Detail:   strings.Join(changes, "\n"),
+		})
+	}
+	diags = append(diags, retentionWarnings(d)...)
 
-	datatype := d.Get("datatype").(string)
+	datatype := datastreamDatatype(d.Get("datatype").(string))
 	datatypeConf := adverityclient.DatastreamDatatypeConfig{
 		Datatype: datatype,
 	}
 	_, err = client.DataStreamChangeDatatype(datatypeConf, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
 
-	enabled := d.Get("enabled").(bool)
-	enabledConf := adverityclient.DataStreamEnablingConfig{
-		Enabled: enabled,
-	}
-	_, enablingErr := client.EnableDatastream(enabledConf, d.Id())
-	if enablingErr != nil {
-		return diag.FromErr(err)
+	// Only send enabled when it changed, so a datastream enabled by adverity_datastream_enablement isn't disabled again
+	if d.HasChange("enabled") {
+		enabled := d.Get("enabled").(bool)
+		enabledConf := adverityclient.DataStreamEnablingConfig{
+			Enabled: enabled,
+		}
+		_, enablingErr := client.EnableDatastream(enabledConf, d.Id())
+		if enablingErr != nil {
+			return append(diags, diag.FromErr(enablingErr)...)
+		}
 	}
 
 	datastream_type_id := d.Get("datastream_type_id").(int)
@@ -615,9 +682,9 @@ func datastreamUpdate(ctx context.Context, d *schema.ResourceData, m interface{}
 	}
 	_, err = client.UpdateDatastreamSpecific(specific_conf, d.Id(), datastream_type_id)
 	if err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
-	return datastreamRead(ctx, d, m)
+	return append(diags, datastreamRead(ctx, d, m)...)
 }
 
 func datastreamDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -661,11 +728,134 @@ func datastreamImportHelper(ctx context.Context, d *schema.ResourceData, m inter
 		return nil, fmt.Errorf("could not convert datastream_type (%s) to an integer", parts[0])
 	}
 	d.Set("datastream_type_id", datastream_type_id)
+	d.Set("allow_destructive_changes", false)
+	d.Set("on_create_failure", "taint")
 	d.SetId(parts[1])
 
 	return []*schema.ResourceData{d}, nil
 }
 
+// retentionTypeNumbers maps the named retention types to the numbers used by the API.
+var retentionTypeNumbers = map[string]int{
+	"all":      1,
+	"fetches":  2,
+	"days":     3,
+	"extracts": 4,
+}
+
+// retentionTypeValues returns all accepted values of retention_type, both the names and the numbers.
+func retentionTypeValues() []string {
+	values := []string{}
+	for name, number := range retentionTypeNumbers {
+		values = append(values, name, strconv.Itoa(number))
+	}
+	sort.Strings(values)
+	return values
+}
+
+// retentionTypeName returns the name of a retention type given either its name or its number. Unknown values are returned as is.
+func retentionTypeName(value string) string {
+	for name, number := range retentionTypeNumbers {
+		if value == strconv.Itoa(number) {
+			return name
+		}
+	}
+	return value
+}
+
+// datastreamDatatypes are the datatypes a datastream can have, as named by the API.
+var datastreamDatatypes = []string{"Live", "Staging"}
+
+// datastreamDatatype returns the datatype as named by the API, regardless of its case. Unknown values are returned as is.
+func datastreamDatatype(value string) string {
+	for _, datatype := range datastreamDatatypes {
+		if strings.EqualFold(value, datatype) {
+			return datatype
+		}
+	}
+	return value
+}
+
+// validateRetention is a CustomizeDiff function that checks retention_number against retention_type: the retention types other
+// than all need a number of at least one. Retaining all data ignores retention_number, which is only warned about after applying
+// by retentionWarnings, since a plan can't show warnings.
+func validateRetention(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("retention_type") || !d.NewValueKnown("retention_number") {
+		return nil
+	}
+	retentionType := retentionTypeName(d.Get("retention_type").(string))
+	retentionNumber := d.Get("retention_number").(int)
+	if retentionType == "all" {
+		if retentionNumber != 0 {
+			log.Printf("[WARN] retention_number of datastream %s is ignored, because retention_type is all", d.Id())
+		}
+		return nil
+	}
+	if retentionNumber < 1 {
+		return fmt.Errorf("retention_number must be set to at least 1 when retention_type is %s", retentionType)
+	}
+	return nil
+}
+
+// retentionWarnings warns that retention_number is ignored when retention_type is all.
+func retentionWarnings(d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if retentionTypeName(d.Get("retention_type").(string)) == "all" && d.Get("retention_number").(int) != 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("retention_number of datastream %s is ignored", d.Id()),
+			Detail:   "All data is retained when retention_type is all, regardless of retention_number. Remove retention_number or choose another retention_type.",
+		})
+	}
+	return diags
+}
+
+// validateDestructiveChanges is a CustomizeDiff function that refuses changes to an existing datastream which can delete data
+// already loaded into the destination, unless allow_destructive_changes is set. The error explains what would be deleted. When
+// the changes are allowed, a plan can't show warnings, so they are only logged here and shown as a warning after applying.
+func validateDestructiveChanges(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	changes := destructiveDatastreamChanges(d, d.NewValueKnown)
+	if len(changes) == 0 {
+		return nil
+	}
+	if d.Get("allow_destructive_changes").(bool) {
+		log.Printf("[WARN] Planning changes to datastream %s that can delete data in the destination: %s", d.Id(), strings.Join(changes, "; "))
+		return nil
+	}
+	return fmt.Errorf("this change to datastream %s can delete data in the destination: %s. Set allow_destructive_changes to true to apply it anyway", d.Id(), strings.Join(changes, "; "))
+}
+
+// destructiveDatastreamChanges returns a description of each change that can delete data already loaded into the destination:
+// turning on overwrite_datastream, limiting the retention when all data was kept, switching to another limited retention_type,
+// and lowering the retention_number. Attributes for which known returns false are skipped.
+func destructiveDatastreamChanges(d interface {
+	GetChange(string) (interface{}, interface{})
+}, known func(string) bool) []string {
+	changes := []string{}
+	if known("overwrite_datastream") {
+		oldValue, newValue := d.GetChange("overwrite_datastream")
+		if !oldValue.(bool) && newValue.(bool) {
+			changes = append(changes, "overwrite_datastream is turned on, so all rows previously loaded by this datastream are dropped from the destination before each new import")
+		}
+	}
+	if known("retention_type") && known("retention_number") {
+		oldType, newType := d.GetChange("retention_type")
+		oldNumber, newNumber := d.GetChange("retention_number")
+		oldRetentionType, newRetentionType := retentionTypeName(oldType.(string)), retentionTypeName(newType.(string))
+		if newRetentionType != "all" && (oldRetentionType != newRetentionType || newNumber.(int) < oldNumber.(int)) {
+			oldRetention := "all data"
+			if oldRetentionType != "all" {
+				oldRetention = fmt.Sprintf("%d %s", oldNumber.(int), oldRetentionType)
+			}
+			changes = append(changes, fmt.Sprintf("retention goes from %s to %d %s, so older data beyond that is deleted from the destination", oldRetention, newNumber.(int), newRetentionType))
+		}
+	}
+	return changes
+}
+
 func randTimeLimited(startTime string, endTime string) string {
 	t1, _ := time.ParseInLocation("15:04", startTime, time.Local)
 	t1u := t1.Unix()
@@ -0,0 +1,236 @@
+package adverity
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devoteamgcloud/adverityclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func datastreamEnablement() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: datastreamEnablementCreate,
+		ReadContext:   datastreamEnablementRead,
+		UpdateContext: datastreamEnablementUpdate,
+		DeleteContext: datastreamEnablementDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: datastreamEnablementImportHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			DATASTREAM_ID: {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the datastream to enable. The enabled attribute of this datastream should be left unset.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the datastream should be enabled or not.",
+			},
+			"destination_mapping": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"mapping_id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the destination mapping.",
+						},
+						DESTINATION_TYPE: {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "The type of the destination of the mapping.",
+						},
+						DESTINATION_ID: {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "The ID of the destination of the mapping.",
+						},
+					},
+				},
+				Description: "The destination mappings of the datastream that have to exist before it is enabled. Referencing the attributes of the adverity_destination_mapping resources here also makes sure they are created first.",
+			},
+			"require_columns": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If set to true, the datastream is only enabled once it has columns. Add the adverity_columns resource of the datastream to depends_on, so the columns are created first.",
+			},
+			"poll_interval": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The number of seconds between checks whether the destination mappings and columns are in place.",
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Description: "This resource enables a datastream only once its destination mappings and columns are in place, so its schedules can't fire before the data has somewhere to go. Destroying this resource disables the datastream again.",
+	}
+}
+
+func datastreamEnablementCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId(strconv.Itoa(d.Get(DATASTREAM_ID).(int)))
+
+	if diags := datastreamEnablementApply(ctx, d, m, d.Timeout(schema.TimeoutCreate)); diags.HasError() {
+		d.SetId("")
+		return diags
+	}
+
+	return datastreamEnablementRead(ctx, d, m)
+}
+
+func datastreamEnablementRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	providerConfig := m.(*config)
+
+	client := *providerConfig.Client
+
+	res, err, code := client.ReadDatastreamByID(d.Id())
+	if err != nil {
+		if code == 404 {
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+	d.Set(DATASTREAM_ID, res.ID)
+	d.Set("enabled", res.Enabled)
+
+	return diags
+}
+
+func datastreamEnablementUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if d.HasChange("enabled") {
+		if diags := datastreamEnablementApply(ctx, d, m, d.Timeout(schema.TimeoutUpdate)); diags.HasError() {
+			return diags
+		}
+	}
+
+	return datastreamEnablementRead(ctx, d, m)
+}
+
+func datastreamEnablementDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	providerConfig := m.(*config)
+
+	client := *providerConfig.Client
+
+	enabledConf := adverityclient.DataStreamEnablingConfig{
+		Enabled: false,
+	}
+	_, err := client.EnableDatastream(enabledConf, d.Id())
+	if err != nil {
+		// A datastream that is already gone doesn't need to be disabled
+		exists, existsErr := client.DatastreamExists(d.Id())
+		if existsErr == nil && !exists {
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func datastreamEnablementImportHelper(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if _, err := strconv.Atoi(d.Id()); err != nil {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected the numeric datastream_id", d.Id())
+	}
+	d.Set("require_columns", false)
+	d.Set("poll_interval", 10)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// datastreamEnablementApply enables or disables the datastream. Before enabling, it waits until the destination mappings and
+// columns of the datastream are in place, and fails if they aren't within the timeout.
+func datastreamEnablementApply(ctx context.Context, d *schema.ResourceData, m interface{}, timeout time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+	enabled := d.Get("enabled").(bool)
+
+	providerConfig := m.(*config)
+
+	client := *providerConfig.Client
+
+	if enabled {
+		interval := time.Duration(d.Get("poll_interval").(int)) * time.Second
+		deadline := time.Now().Add(timeout)
+		for {
+			missing, err := datastreamEnablementMissing(client, d)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if len(missing) == 0 {
+				break
+			}
+			if !time.Now().Before(deadline) {
+				return diag.Errorf("Datastream %s was not enabled, because it is still missing %s", d.Id(), strings.Join(missing, " and "))
+			}
+			log.Printf("[DEBUG] Waiting to enable datastream %s, still missing %s", d.Id(), strings.Join(missing, " and "))
+			select {
+			case <-ctx.Done():
+				return diag.FromErr(ctx.Err())
+			case <-time.After(interval):
+			}
+		}
+	}
+
+	enabledConf := adverityclient.DataStreamEnablingConfig{
+		Enabled: enabled,
+	}
+	_, err := client.EnableDatastream(enabledConf, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// datastreamEnablementMissing returns what still has to be in place before the datastream can be enabled.
+func datastreamEnablementMissing(client adverityclient.Client, d *schema.ResourceData) ([]string, error) {
+	missing := []string{}
+	for _, mapping := range d.Get("destination_mapping").(*schema.Set).List() {
+		settings := mapping.(map[string]interface{})
+		mappingId := settings["mapping_id"].(string)
+		id, err := strconv.Atoi(mappingId)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected format of destination mapping ID (%s), expected a number", mappingId)
+		}
+		res, err, code := client.ReadDestinationMapping(id, settings[DESTINATION_TYPE].(int), settings[DESTINATION_ID].(int))
+		if err != nil {
+			if code == 404 {
+				missing = append(missing, fmt.Sprintf("destination mapping %s", mappingId))
+				continue
+			}
+			return nil, err
+		}
+		if res.Datastream != d.Get(DATASTREAM_ID).(int) {
+			return nil, fmt.Errorf("destination mapping %s belongs to datastream %d, not to datastream %d", mappingId, res.Datastream, d.Get(DATASTREAM_ID).(int))
+		}
+	}
+	if d.Get("require_columns").(bool) {
+		columns, err := client.ReadColumns(d.Id())
+		if err != nil {
+			return nil, err
+		}
+		if len(columns) == 0 {
+			missing = append(missing, "its columns")
+		}
+	}
+	return missing, nil
+}
@@ -28,7 +28,8 @@ func datatypeMapping() *schema.Resource {
 			},
 			"schema": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
+				ExactlyOneOf: []string{"schema", "column"},
 				ValidateFunc: validation.StringIsJSON,
 				StateFunc: func(v interface{}) string {
 					jsonString, _ := structure.NormalizeJsonString(v)
@@ -37,17 +38,56 @@ func datatypeMapping() *schema.Resource {
 					if err != nil {
 						return ""
 					}
-					var noModeElements []adverityclient.SchemaElementNoMode
-					for _, modeElement := range modeElements {
-						noModeElements = append(noModeElements, adverityclient.SchemaElementNoMode{
-							Type: modeElement.Type,
-							Name: modeElement.Name,
-						})
-					}
-					bytes, _ := json.Marshal(noModeElements)
+					bytes, _ := json.Marshal(modeElements)
 					return string(bytes[:])
 				},
-				Description: "A JSON schema, as extracted from a BigQuery table.",
+				Description: "A JSON schema, as extracted from a BigQuery table. Either this or column blocks must be given. Nested RECORD columns and the mode of columns are supported, see nested_fields_strategy.",
+			},
+			"column": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				ExactlyOneOf: []string{"schema", "column"},
+				Set:          columnDefinitionHash,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the column.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The datatype of the column in the warehouse, for example STRING or INTEGER. Must be a datatype of the chosen type_dialect.",
+						},
+						"key": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether the column is part of the key of a row.",
+						},
+						"mapped": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the column has been schema mapped (has a target column).",
+						},
+					},
+				},
+				Description: "The columns and their datatype, as an alternative to the JSON schema. Changes to a single column show up as a change to that column in the plan.",
+			},
+			"type_dialect": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "bigquery",
+				ValidateFunc: validation.StringInSlice(typeDialectNames(), false),
+				Description:  "The warehouse the datatypes in the schema or column blocks belong to. One of bigquery, postgres, redshift or snowflake.",
+			},
+			"nested_fields_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      nestedFieldsJSON,
+				ValidateFunc: validation.StringInSlice([]string{nestedFieldsJSON, nestedFieldsFlatten}, false),
+				Description:  "How RECORD columns in the JSON schema are mapped. 'json' maps them to a single JSON column, 'flatten' maps every field to the column named parent_field (or parent.field if replace_special_characters is false). REPEATED columns are always mapped to JSON columns.",
 			},
 			"populating_settings": {
 				Type:     schema.TypeList,
@@ -97,6 +137,7 @@ func datatypeMapping() *schema.Resource {
 				Description: "If set to true, special characters in Adverity will be replaced by underscores, and names beginning with a number will start with an \"n\" instead.",
 			},
 		},
+		CustomizeDiff: validateColumnTypes,
 		CreateContext: datatypeMappingCreate,
 		ReadContext:   datatypeMappingRead,
 		UpdateContext: datatypeMappingUpdate,
@@ -111,14 +152,9 @@ func datatypeMapping() *schema.Resource {
 
 func datatypeMappingRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	typeMapping := map[string]string{
-		"String":   "STRING",
-		"Long":     "INTEGER",
-		"Float":    "FLOAT",
-		"Date":     "DATE",
-		"DateTime": "DATETIME",
-		"Boolean":  "BOOLEAN",
-		"JSON":     "JSON",
+	dialect, err := lookupTypeDialect(d.Get("type_dialect").(string))
+	if err != nil {
+		return diag.FromErr(err)
 	}
 	datastreamID := d.Get("datastream_id").(string)
 	providerConfig := m.(*config)
@@ -136,9 +172,8 @@ func datatypeMappingRead(ctx context.Context, d *schema.ResourceData, m interfac
 	if d.Get("replace_special_characters").(bool) {
 		columns = replaceSpecialCharacters(columns)
 	}
-	var existingSchema []adverityclient.SchemaElementNoMode
-	schemaText := d.Get("schema").(string)
-	if err := json.Unmarshal([]byte(schemaText), &existingSchema); err != nil {
+	existingElements, _, usesBlocks, err := datatypeMappingDefinedSchema(d)
+	if err != nil {
 		return diag.FromErr(err)
 	}
 	ignoredColumns := []string{}
@@ -147,69 +182,62 @@ func datatypeMappingRead(ctx context.Context, d *schema.ResourceData, m interfac
 			ignoredColumns = append(ignoredColumns, column.(string))
 		}
 	}
-	var readSchema []adverityclient.SchemaElementNoMode
-	// For every column defined in the input schema
-	for _, existingColumn := range existingSchema {
-		found := false
-		// Go over every column that has been read from the Adverity API
-		for idx, column := range columns {
-			// Check if the names match
-			if existingColumn.Name == column.Name {
-				// Add the column read from the API to the read schema
-				readSchema = append(readSchema, adverityclient.SchemaElementNoMode{
-					Type: typeMapping[column.DataType],
-					Name: column.Name,
-				})
-				// Remove that column from the list of columns read from the API
-				columns = append(columns[0:idx], columns[idx+1:]...)
-				found = true
-				break
-			}
+	// Columns defined in the input schema are read back in the same order and with the same nesting, ignored columns that
+	// don't exist in Adverity are kept so Terraform doesn't detect drift, and columns only found in Adverity are added at the end
+	readSchema := readSchemaElements(existingElements, columns, d.Get("nested_fields_strategy").(string), datatypeMappingSeparator(d), dialect, ignoredColumns)
+	if usesBlocks {
+		keys := map[string]bool{}
+		for _, column := range d.Get("column").(*schema.Set).List() {
+			c := column.(map[string]interface{})
+			keys[c["name"].(string)] = c["key"].(bool)
 		}
-		// If the column wasn't found in the Adverity API, it may still be one of the ignored columns. If this is the case, it has to be
-		// added to the schema regardless, otherwise Terraform will (correctly) detect drift, and will try to change it. Since it is an ignored
-		// column, we don't need/want Terraform to do any changes to these columns.
-		if !found {
-			// Go over all columns in the ignored list
-			for _, ignoredColumn := range ignoredColumns {
-				// If the column defined in the input schema matches a column name in the ignored list
-				if existingColumn.Name == ignoredColumn {
-					// Add it to the read schema
-					readSchema = append(readSchema, existingColumn)
-					break
-				}
-			}
+		for _, column := range columns {
+			keys[column.Name] = column.IsKeyColumn
 		}
+		columnBlocks := []interface{}{}
+		for _, column := range readSchema {
+			columnBlocks = append(columnBlocks, map[string]interface{}{
+				"name":   column.Name,
+				"type":   column.Type,
+				"key":    keys[column.Name],
+				"mapped": column.Mapped,
+			})
+		}
+		if err := d.Set("column", columnBlocks); err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		bytes, _ := json.Marshal(withoutMapped(readSchema))
+		d.Set("schema", string(bytes[:]))
 	}
-	// For every remaining column that has been read in the API (and thus had no match in the input schema)
-	for _, column := range columns {
-		// Add it to the read schema
-		readSchema = append(readSchema, adverityclient.SchemaElementNoMode{
-			Type: typeMapping[column.DataType],
-			Name: column.Name,
-		})
-	}
-	bytes, _ := json.Marshal(readSchema)
-	d.Set("schema", string(bytes[:]))
 	return diags
 }
 
+// withoutMapped clears the mapped flag of every (nested) schema element, since this resource doesn't keep track of it.
+func withoutMapped(elements []adverityclient.SchemaElementMode) []adverityclient.SchemaElementMode {
+	cleared := []adverityclient.SchemaElementMode{}
+	for _, element := range elements {
+		element.Mapped = false
+		element.Fields = withoutMapped(element.Fields)
+		if len(element.Fields) == 0 {
+			element.Fields = nil
+		}
+		cleared = append(cleared, element)
+	}
+	return cleared
+}
+
 func datatypeMappingCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	typeMapping := map[string]string{
-		"STRING":   "String",
-		"INTEGER":  "Long",
-		"FLOAT":    "Float",
-		"DATE":     "Date",
-		"DATETIME": "DateTime",
-		"BOOLEAN":  "Boolean",
-		"JSON":     "JSON",
+	dialect, err := lookupTypeDialect(d.Get("type_dialect").(string))
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	schemaText := d.Get("schema").(string)
-	var schema []adverityclient.SchemaElementNoMode
-	if err := json.Unmarshal([]byte(schemaText), &schema); err != nil {
+	_, schema, usesBlocks, err := datatypeMappingDefinedSchema(d)
+	if err != nil {
 		return diag.FromErr(err)
 	}
+	diags = append(diags, relaxedModeWarnings(schema)...)
 	providerConfig := m.(*config)
 	client := *providerConfig.Client
 	datastreamID := d.Get("datastream_id").(string)
@@ -243,9 +271,19 @@ func datatypeMappingCreate(ctx context.Context, d *schema.ResourceData, m interf
 			for idx, targetColumn := range schema {
 				if column.Name == targetColumn.Name {
 					found = true
-					if !column.ConfirmedType || column.DataType != typeMapping[targetColumn.Type] {
-						client.PatchColumn(strconv.Itoa(column.ID), typeMapping[targetColumn.Type])
-						log.Printf("[DEBUG] Patch request goes here to change %s with type %s to type %s", column.Name, column.DataType, typeMapping[targetColumn.Type])
+					targetType, err := targetColumn.adverityType(dialect)
+					if err != nil {
+						return diag.FromErr(err)
+					}
+					if !column.ConfirmedType || column.DataType != targetType {
+						client.PatchColumn(strconv.Itoa(column.ID), targetType)
+						log.Printf("[DEBUG] Patch request goes here to change %s with type %s to type %s", column.Name, column.DataType, targetType)
+					}
+					// Keys can only be set with column blocks, so keys set elsewhere are left alone when using the JSON schema
+					if usesBlocks && column.IsKeyColumn != targetColumn.Key {
+						if err := client.PatchColumnKey(strconv.Itoa(column.ID), targetColumn.Key); err != nil {
+							return diag.FromErr(err)
+						}
 					}
 					// Remove found item from list to search
 					schema = append(schema[0:idx], schema[idx+1:]...)
@@ -314,20 +352,15 @@ func datatypeMappingCreate(ctx context.Context, d *schema.ResourceData, m interf
 
 func datatypeMappingUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
-	typeMapping := map[string]string{
-		"STRING":   "String",
-		"INTEGER":  "Long",
-		"FLOAT":    "Float",
-		"DATE":     "Date",
-		"DATETIME": "DateTime",
-		"BOOLEAN":  "Boolean",
-		"JSON":     "JSON",
+	dialect, err := lookupTypeDialect(d.Get("type_dialect").(string))
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	schemaText := d.Get("schema").(string)
-	var schema []adverityclient.SchemaElementNoMode
-	if err := json.Unmarshal([]byte(schemaText), &schema); err != nil {
+	_, schema, usesBlocks, err := datatypeMappingDefinedSchema(d)
+	if err != nil {
 		return diag.FromErr(err)
 	}
+	diags = append(diags, relaxedModeWarnings(schema)...)
 	providerConfig := m.(*config)
 	client := *providerConfig.Client
 	datastreamID := d.Get("datastream_id").(string)
@@ -361,9 +394,19 @@ func datatypeMappingUpdate(ctx context.Context, d *schema.ResourceData, m interf
 			for idx, targetColumn := range schema {
 				if column.Name == targetColumn.Name {
 					found = true
-					if !column.ConfirmedType || column.DataType != typeMapping[targetColumn.Type] {
-						client.PatchColumn(strconv.Itoa(column.ID), typeMapping[targetColumn.Type])
-						log.Printf("[DEBUG] Patch request goes here to change %s with type %s to type %s", column.Name, column.DataType, typeMapping[targetColumn.Type])
+					targetType, err := targetColumn.adverityType(dialect)
+					if err != nil {
+						return diag.FromErr(err)
+					}
+					if !column.ConfirmedType || column.DataType != targetType {
+						client.PatchColumn(strconv.Itoa(column.ID), targetType)
+						log.Printf("[DEBUG] Patch request goes here to change %s with type %s to type %s", column.Name, column.DataType, targetType)
+					}
+					// Keys can only be set with column blocks, so keys set elsewhere are left alone when using the JSON schema
+					if usesBlocks && column.IsKeyColumn != targetColumn.Key {
+						if err := client.PatchColumnKey(strconv.Itoa(column.ID), targetColumn.Key); err != nil {
+							return diag.FromErr(err)
+						}
 					}
 					// Remove found item from list to search
 					schema = append(schema[0:idx], schema[idx+1:]...)
@@ -449,3 +492,46 @@ func replaceSpecialCharacters(columns []adverityclient.Column) []adverityclient.
 	}
 	return replacedColumns
 }
+
+// datatypeMappingDefinedSchema returns the columns defined in the resource as schema elements, the flat list of columns they
+// map to in Adverity, and whether they were defined with column blocks instead of the JSON schema.
+func datatypeMappingDefinedSchema(d *schema.ResourceData) ([]adverityclient.SchemaElementMode, []columnDefinition, bool, error) {
+	definedSchema := []adverityclient.SchemaElementMode{}
+	schemaText := d.Get("schema").(string)
+	usesBlocks := schemaText == ""
+	if usesBlocks {
+		for _, column := range d.Get("column").(*schema.Set).List() {
+			c := column.(map[string]interface{})
+			definedSchema = append(definedSchema, adverityclient.SchemaElementMode{
+				Name: c["name"].(string),
+				Type: c["type"].(string),
+			})
+		}
+	} else if err := json.Unmarshal([]byte(schemaText), &definedSchema); err != nil {
+		return nil, nil, false, err
+	}
+	definitions, err := expandSchemaElements(definedSchema, d.Get("nested_fields_strategy").(string), datatypeMappingSeparator(d))
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if usesBlocks {
+		keys := map[string]bool{}
+		for _, column := range d.Get("column").(*schema.Set).List() {
+			c := column.(map[string]interface{})
+			keys[c["name"].(string)] = c["key"].(bool)
+		}
+		for i := range definitions {
+			definitions[i].Key = keys[definitions[i].Name]
+		}
+	}
+	return definedSchema, definitions, usesBlocks, nil
+}
+
+// datatypeMappingSeparator returns the separator between the names of a record and its fields when flattening, which has to
+// match the column names after special characters have been replaced.
+func datatypeMappingSeparator(d *schema.ResourceData) string {
+	if d.Get("replace_special_characters").(bool) {
+		return "_"
+	}
+	return "."
+}
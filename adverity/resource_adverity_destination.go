@@ -3,12 +3,14 @@ package adverity
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/devoteamgcloud/adverityclient"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func destination() *schema.Resource {
@@ -39,14 +41,113 @@ func destination() *schema.Resource {
 				Description: "The type ID of the destination.",
 			},
 			PROJECT_ID: {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The GCP project ID.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: destinationWarehouses,
+				RequiredWith: []string{PROJECT_ID, DATASET_ID},
+				Description:  "The GCP project ID. Required for BigQuery destinations.",
 			},
 			DATASET_ID: {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The ID of the BigQuery dataset.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{PROJECT_ID, DATASET_ID},
+				Description:  "The ID of the BigQuery dataset. Required for BigQuery destinations.",
+			},
+			"snowflake": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: destinationWarehouses,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The Snowflake account identifier.",
+						},
+						"warehouse": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The Snowflake warehouse used to load the data.",
+						},
+						"database": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The database the tables are created in.",
+						},
+						"schema": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The schema the tables are created in.",
+						},
+						"role": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The role used to load the data. Uses the default role of the user if not set.",
+						},
+					},
+				},
+				Description: "The settings of a Snowflake destination.",
+			},
+			"redshift": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: destinationWarehouses,
+				Elem:         databaseDestinationResource(5439, true),
+				Description:  "The settings of a Redshift destination.",
+			},
+			"postgres": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: destinationWarehouses,
+				Elem:         databaseDestinationResource(5432, true),
+				Description:  "The settings of a PostgreSQL destination.",
+			},
+			"mysql": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: destinationWarehouses,
+				Elem:         databaseDestinationResource(3306, false),
+				Description:  "The settings of a MySQL destination.",
+			},
+			"azure_synapse": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: destinationWarehouses,
+				Elem:         databaseDestinationResource(1433, true),
+				Description:  "The settings of an Azure Synapse destination.",
+			},
+			"file": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: destinationWarehouses,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bucket": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The S3 or GCS bucket the files are written to.",
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The path within the bucket the files are written to.",
+						},
+						"file_format": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "csv",
+							ValidateFunc: validation.StringInSlice([]string{"csv", "json", "avro", "parquet"}, false),
+							Description:  "The format of the files. One of csv, json, avro or parquet.",
+						},
+					},
+				},
+				Description: "The settings of an S3 or GCS file destination. Whether S3 or GCS is used depends on the destination_type.",
 			},
 			AUTH: {
 				Type:        schema.TypeInt,
@@ -59,12 +160,40 @@ func destination() *schema.Resource {
 				Description: "If set to false, schema mapping will be turned off for this destination.",
 			},
 			HEADERS_FORMATTING: {
-				Type:        schema.TypeInt,
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(headersFormattingValues(), false),
+				StateFunc: func(v interface{}) string {
+					return headersFormattingName(v.(string))
+				},
+				Description: "The style of header formatting. One of snake_case, camel_case or original. The numbers 1, 2 and 3 are accepted as well, for backwards compatibility.",
+			},
+			"force_string": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "If set to true, all columns are loaded into the destination as strings.",
+			},
+			"format_headers": {
+				Type:        schema.TypeBool,
 				Optional:    true,
-				Description: "A number corresponding to a style of header formatting.",
+				Computed:    true,
+				Description: "If set to true, the column names are formatted according to headers_formatting.",
+			},
+			"column_names_to_lowercase": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "If set to true, the column names are converted to lowercase.",
+			},
+			"is_schema_mapping_required": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether this type of destination requires schema mapping. If true, schema_mapping can't be set to false.",
 			},
 		},
-		Description: "This resource will create a destination. Currently only supports BigQuery destinations.",
+		CustomizeDiff: validateSchemaMapping,
+		Description:   "This resource will create a destination. BigQuery destinations are configured with project_id and dataset_id, other warehouses with exactly one of the snowflake, redshift, postgres, mysql, azure_synapse or file blocks. The destination_type has to match the chosen warehouse.",
 	}
 }
 
@@ -73,14 +202,8 @@ func destinationCreate(ctx context.Context, d *schema.ResourceData, m interface{
 	stack := d.Get(STACK).(int)
 	auth := d.Get(AUTH).(int)
 	destinationType := d.Get(DESTINATION_TYPE).(int)
-	projectId := d.Get(PROJECT_ID).(string)
-	datasetId := d.Get(DATASET_ID).(string)
 	schemaMapping := d.Get(SCHEMA_MAPPING).(bool)
-	headersFormatting := d.Get(HEADERS_FORMATTING).(int)
-
-	if headersFormatting <= 0 || headersFormatting > 3 {
-		return diag.Errorf("Could not create Destination. Invalid value %d for headers_formatting. Only 1, 2, or 3 is allowed.", headersFormatting)
-	}
+	headersFormatting := headersFormattingNumbers[headersFormattingName(d.Get(HEADERS_FORMATTING).(string))]
 
 	providerConfig := m.(*config)
 
@@ -89,12 +212,12 @@ func destinationCreate(ctx context.Context, d *schema.ResourceData, m interface{
 	conf := adverityclient.DestinationConfig{
 		Name:              name,
 		Stack:             stack,
-		ProjectID:         projectId,
-		DatasetID:         datasetId,
 		Auth:              auth,
 		SchemaMapping:     schemaMapping,
 		HeadersFormatting: headersFormatting,
 	}
+	expandDestinationWarehouse(d, &conf)
+	expandDestinationOptions(d, &conf)
 
 	res, err := client.CreateDestination(conf, destinationType)
 
@@ -124,13 +247,18 @@ func destinationRead(ctx context.Context, d *schema.ResourceData, m interface{})
 	}
 
 	d.SetId(d.Id())
-	d.Set(PROJECT_ID, res.Project)
 	d.Set(STACK, res.Stack)
 	d.Set(AUTH, res.Auth)
-	d.Set(DATASET_ID, res.Dataset)
 	d.Set(NAME, res.Name)
+	if err := setDestinationWarehouse(d, res); err != nil {
+		return diag.FromErr(err)
+	}
 	d.Set(SCHEMA_MAPPING, res.SchemaMapping)
-	d.Set(HEADERS_FORMATTING, res.HeadersFormatting)
+	d.Set(HEADERS_FORMATTING, headersFormattingName(strconv.Itoa(res.HeadersFormatting)))
+	d.Set("force_string", res.ForceString)
+	d.Set("format_headers", res.FormatHeaders)
+	d.Set("column_names_to_lowercase", res.ColumnNamesToLowercase)
+	d.Set("is_schema_mapping_required", res.IsSchemaMappingRequired)
 
 	return diags
 }
@@ -140,14 +268,8 @@ func destinationUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 	stack := d.Get(STACK).(int)
 	auth := d.Get(AUTH).(int)
 	destinationType := d.Get(DESTINATION_TYPE).(int)
-	projectId := d.Get(PROJECT_ID).(string)
-	datasetId := d.Get(DATASET_ID).(string)
 	schemaMapping := d.Get(SCHEMA_MAPPING).(bool)
-	headersFormatting := d.Get(HEADERS_FORMATTING).(int)
-
-	if headersFormatting <= 0 || headersFormatting > 3 {
-		return diag.Errorf("Could not create Destination. Invalid value %d for headers_formatting. Only 1, 2, or 3 is allowed.", headersFormatting)
-	}
+	headersFormatting := headersFormattingNumbers[headersFormattingName(d.Get(HEADERS_FORMATTING).(string))]
 
 	providerConfig := m.(*config)
 
@@ -156,12 +278,12 @@ func destinationUpdate(ctx context.Context, d *schema.ResourceData, m interface{
 	conf := adverityclient.DestinationConfig{
 		Name:              name,
 		Stack:             stack,
-		ProjectID:         projectId,
-		DatasetID:         datasetId,
 		Auth:              auth,
 		SchemaMapping:     schemaMapping,
 		HeadersFormatting: headersFormatting,
 	}
+	expandDestinationWarehouse(d, &conf)
+	expandDestinationOptions(d, &conf)
 
 	_, err := client.UpdateDestination(conf, destinationType, d.Id())
 
@@ -201,3 +323,206 @@ func destinationImportHelper(ctx context.Context, d *schema.ResourceData, m inte
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// destinationWarehouses are the mutually exclusive ways of configuring the warehouse of a destination. BigQuery destinations
+// use the top level project_id and dataset_id, all other warehouses have their own block.
+var destinationWarehouses = []string{PROJECT_ID, "snowflake", "redshift", "postgres", "mysql", "azure_synapse", "file"}
+
+// databaseDestinationResource returns the block used by warehouses that are reached through a host and database.
+func databaseDestinationResource(defaultPort int, hasSchema bool) *schema.Resource {
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"host": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The hostname of the server.",
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultPort,
+				ValidateFunc: validation.IsPortNumber,
+				Description:  fmt.Sprintf("The port of the server. Defaults to %d.", defaultPort),
+			},
+			"database": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The database the tables are created in.",
+			},
+		},
+	}
+	if hasSchema {
+		resource.Schema["schema"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The schema the tables are created in.",
+		}
+	}
+	return resource
+}
+
+// expandDestinationWarehouse adds the settings of the configured warehouse to the destination config.
+func expandDestinationWarehouse(d *schema.ResourceData, conf *adverityclient.DestinationConfig) {
+	conf.ProjectID = d.Get(PROJECT_ID).(string)
+	conf.DatasetID = d.Get(DATASET_ID).(string)
+	if block, exists := d.GetOk("snowflake.0"); exists {
+		settings := block.(map[string]interface{})
+		conf.Account = settings["account"].(string)
+		conf.Warehouse = settings["warehouse"].(string)
+		conf.Database = settings["database"].(string)
+		conf.Schema = settings["schema"].(string)
+		conf.Role = settings["role"].(string)
+	}
+	for _, warehouse := range []string{"redshift", "postgres", "mysql", "azure_synapse"} {
+		if block, exists := d.GetOk(warehouse + ".0"); exists {
+			settings := block.(map[string]interface{})
+			conf.Host = settings["host"].(string)
+			conf.Port = settings["port"].(int)
+			conf.Database = settings["database"].(string)
+			if schemaName, exists := settings["schema"]; exists {
+				conf.Schema = schemaName.(string)
+			}
+		}
+	}
+	if block, exists := d.GetOk("file.0"); exists {
+		settings := block.(map[string]interface{})
+		conf.Bucket = settings["bucket"].(string)
+		conf.Path = settings["path"].(string)
+		conf.FileFormat = settings["file_format"].(string)
+	}
+}
+
+// setDestinationWarehouse reads the settings of the warehouse back into the block that is configured. When nothing is
+// configured yet, for example after an import, the warehouse is derived from the settings returned by the API. Warehouses
+// reached through a host can't be told apart, so their block only shows up once it is configured.
+func setDestinationWarehouse(d *schema.ResourceData, res *adverityclient.Destination) error {
+	warehouse := ""
+	for _, name := range destinationWarehouses {
+		if _, exists := d.GetOk(name); exists {
+			warehouse = name
+			break
+		}
+	}
+	if warehouse == "" {
+		switch {
+		case res.Project != "":
+			warehouse = PROJECT_ID
+		case res.Account != "":
+			warehouse = "snowflake"
+		case res.Bucket != "":
+			warehouse = "file"
+		}
+	}
+	switch warehouse {
+	case PROJECT_ID:
+		d.Set(PROJECT_ID, res.Project)
+		d.Set(DATASET_ID, res.Dataset)
+	case "snowflake":
+		return d.Set(warehouse, []interface{}{
+			map[string]interface{}{
+				"account":   res.Account,
+				"warehouse": res.Warehouse,
+				"database":  res.Database,
+				"schema":    res.Schema,
+				"role":      res.Role,
+			},
+		})
+	case "redshift", "postgres", "azure_synapse":
+		return d.Set(warehouse, []interface{}{
+			map[string]interface{}{
+				"host":     res.Host,
+				"port":     res.Port,
+				"database": res.Database,
+				"schema":   res.Schema,
+			},
+		})
+	case "mysql":
+		return d.Set(warehouse, []interface{}{
+			map[string]interface{}{
+				"host":     res.Host,
+				"port":     res.Port,
+				"database": res.Database,
+			},
+		})
+	case "file":
+		return d.Set(warehouse, []interface{}{
+			map[string]interface{}{
+				"bucket":      res.Bucket,
+				"path":        res.Path,
+				"file_format": res.FileFormat,
+			},
+		})
+	}
+	return nil
+}
+
+// expandDestinationOptions adds the formatting options that have been set to the destination config. Options that are not set
+// are left out, so the defaults of the destination type are used.
+func expandDestinationOptions(d *schema.ResourceData, conf *adverityclient.DestinationConfig) {
+	// GetOkExists is deprecated, but GetOk can't tell an option set to false apart from an option that isn't set
+	if forceString, exists := d.GetOkExists("force_string"); exists {
+		force := forceString.(bool)
+		conf.ForceString = &force
+	}
+	if formatHeaders, exists := d.GetOkExists("format_headers"); exists {
+		format := formatHeaders.(bool)
+		conf.FormatHeaders = &format
+	}
+	if columnNamesToLowercase, exists := d.GetOkExists("column_names_to_lowercase"); exists {
+		lowercase := columnNamesToLowercase.(bool)
+		conf.ColumnNamesToLowercase = &lowercase
+	}
+}
+
+// validateSchemaMapping is a CustomizeDiff function that stops schema mapping from being turned off for destinations that
+// require it. For new destinations, or when the destination type changes, this is looked up from the planned destination type.
+func validateSchemaMapping(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown(SCHEMA_MAPPING) || d.Get(SCHEMA_MAPPING).(bool) {
+		return nil
+	}
+	required := d.Get("is_schema_mapping_required").(bool)
+	if d.Id() == "" || d.HasChange(DESTINATION_TYPE) {
+		if !d.NewValueKnown(DESTINATION_TYPE) {
+			return nil
+		}
+		providerConfig := m.(*config)
+		client := *providerConfig.Client
+		destinationType, err, _ := client.ReadDestinationType(d.Get(DESTINATION_TYPE).(int))
+		if err != nil {
+			return err
+		}
+		required = destinationType.IsSchemaMappingRequired
+	}
+	if required {
+		return fmt.Errorf("schema_mapping can't be set to false, because schema mapping is required for this type of destination")
+	}
+	return nil
+}
+
+// headersFormattingNumbers maps the named styles of header formatting to the numbers used by the API.
+var headersFormattingNumbers = map[string]int{
+	"snake_case": 1,
+	"camel_case": 2,
+	"original":   3,
+}
+
+// headersFormattingValues returns all accepted values of headers_formatting, both the names and the numbers.
+func headersFormattingValues() []string {
+	values := []string{}
+	for name, number := range headersFormattingNumbers {
+		values = append(values, name, strconv.Itoa(number))
+	}
+	sort.Strings(values)
+	return values
+}
+
+// headersFormattingName returns the name of a style of header formatting given either its name or its number. Unknown values
+// are returned as is.
+func headersFormattingName(value string) string {
+	for name, number := range headersFormattingNumbers {
+		if value == strconv.Itoa(number) {
+			return name
+		}
+	}
+	return value
+}
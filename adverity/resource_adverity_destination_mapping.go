@@ -9,6 +9,7 @@ import (
 	"github.com/devoteamgcloud/adverityclient"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func destinationMapping() *schema.Resource {
@@ -45,6 +46,40 @@ func destinationMapping() *schema.Resource {
 				Required:    true,
 				Description: "The name of the table in this destination the datstream should write to. This will create a table if none exists.",
 			},
+			"partition_column": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The column the BigQuery table is partitioned on. Must be a DATE, DATETIME or TIMESTAMP column, or an INTEGER column for range partitioning.",
+			},
+			"partition_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"partition_column"},
+				ValidateFunc: validation.StringInSlice([]string{"HOUR", "DAY", "MONTH", "YEAR"}, false),
+				Description:  "The granularity of the time partitioning. One of HOUR, DAY, MONTH or YEAR. Requires partition_column.",
+			},
+			"clustering_fields": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 4,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The columns the BigQuery table is clustered by, in order. At most 4 columns can be given.",
+			},
+			"write_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"append", "truncate", "upsert"}, false),
+				Description:  "How the data of a fetch is written to the table. One of append, truncate or upsert. Upsert uses the key columns of the datastream.",
+			},
+			"table_expiration_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "The number of days after which the table expires. 0 means the table never expires.",
+			},
 			"datastream_enabled": {
 				Type:       schema.TypeBool,
 				Optional:   true,
@@ -67,8 +102,13 @@ func destinationMappingCreate(ctx context.Context, d *schema.ResourceData, m int
 	client := *providerConfig.Client
 
 	conf := adverityclient.DestinationMappingConfig{
-		Datastream: datastream_id,
-		TableName:  table_name,
+		Datastream:       datastream_id,
+		TableName:        table_name,
+		PartitionColumn:  d.Get("partition_column").(string),
+		PartitionType:    d.Get("partition_type").(string),
+		ClusteringFields: expandClusteringFields(d),
+		WriteMode:        d.Get("write_mode").(string),
+		TableExpiration:  d.Get("table_expiration_days").(int),
 	}
 
 	res, err := client.CreateDestinationMapping(conf, destination_type, destination_id)
@@ -106,6 +146,11 @@ func destinationMappingRead(ctx context.Context, d *schema.ResourceData, m inter
 	d.Set(DESTINATION_ID, res.Target)
 	d.Set(DATASTREAM_ID, res.Datastream)
 	d.Set(TABLE_NAME, res.TableName)
+	d.Set("partition_column", res.PartitionColumn)
+	d.Set("partition_type", res.PartitionType)
+	d.Set("clustering_fields", res.ClusteringFields)
+	d.Set("write_mode", res.WriteMode)
+	d.Set("table_expiration_days", res.TableExpiration)
 
 	return diags
 }
@@ -123,9 +168,21 @@ func destinationMappingUpdate(ctx context.Context, d *schema.ResourceData, m int
 	providerConfig := m.(*config)
 	client := *providerConfig.Client
 
-	conf := adverityclient.DestinationMappingConfig{
-		Datastream: datastream_id,
-		TableName:  table_name,
+	conf := adverityclient.DestinationMappingUpdateConfig{
+		Datastream:       datastream_id,
+		TableName:        table_name,
+		ClusteringFields: expandClusteringFields(d),
+		WriteMode:        d.Get("write_mode").(string),
+	}
+	// Settings that are removed are sent as null, so they are also removed in Adverity
+	if partitionColumn := d.Get("partition_column").(string); partitionColumn != "" {
+		conf.PartitionColumn = &partitionColumn
+	}
+	if partitionType := d.Get("partition_type").(string); partitionType != "" {
+		conf.PartitionType = &partitionType
+	}
+	if tableExpiration := d.Get("table_expiration_days").(int); tableExpiration != 0 {
+		conf.TableExpiration = &tableExpiration
 	}
 
 	_, err := client.UpdateDestinationMapping(conf, destination_type, destination_id, id)
@@ -157,21 +214,74 @@ func destinationMappingDelete(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func destinationMappingImportHelper(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	parts := strings.SplitN(d.Id(), ":", 3)
-	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
-		return nil, fmt.Errorf("unexpected format of ID (%s), expected destination_type:destination_id:destinationmapping_id", d.Id())
+	parts := strings.Split(d.Id(), ":")
+	for _, part := range parts {
+		if part == "" {
+			parts = nil
+		}
 	}
-	destination_type, err := strconv.Atoi(parts[0])
+	switch len(parts) {
+	case 2:
+		return destinationMappingImportByDatastream(d, m, parts[0], parts[1])
+	case 3:
+		destination_type, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("could not convert destination_type (%s) to an integer", parts[0])
+		}
+		destination_id, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("could not convert destination_id (%s) to an integer", parts[1])
+		}
+		d.Set(DESTINATION_TYPE, destination_type)
+		d.Set(DESTINATION_ID, destination_id)
+		d.SetId(parts[2])
+		return []*schema.ResourceData{d}, nil
+	}
+	return nil, fmt.Errorf("unexpected format of ID (%s), expected datastream_id:destination_id or destination_type:destination_id:destinationmapping_id", d.Id())
+}
+
+// destinationMappingImportByDatastream finds the mapping of a datastream to a destination, so it can be imported without
+// knowing the destination type or the ID of the mapping. Only the mappings of the given destination are listed.
+func destinationMappingImportByDatastream(d *schema.ResourceData, m interface{}, datastreamID string, destinationID string) ([]*schema.ResourceData, error) {
+	datastream_id, err := strconv.Atoi(datastreamID)
 	if err != nil {
-		return nil, fmt.Errorf("could not convert destination_type (%s) to an integer", parts[0])
+		return nil, fmt.Errorf("could not convert datastream_id (%s) to an integer", datastreamID)
 	}
-	destination_id, err := strconv.Atoi(parts[1])
+	destination_id, err := strconv.Atoi(destinationID)
 	if err != nil {
-		return nil, fmt.Errorf("could not convert destination_id (%s) to an integer", parts[1])
+		return nil, fmt.Errorf("could not convert destination_id (%s) to an integer", destinationID)
 	}
-	d.Set(DESTINATION_TYPE, destination_type)
-	d.Set(DESTINATION_ID, destination_id)
-	d.SetId(parts[2])
+	providerConfig := m.(*config)
+	client := *providerConfig.Client
+	destination_type, err := client.FindDestinationType(destination_id)
+	if err != nil {
+		return nil, err
+	}
+	filters := []adverityclient.Query{
+		{
+			Key:   "datastream",
+			Value: strconv.Itoa(datastream_id),
+		},
+	}
+	mappings, err := client.ListDestinationMappings(destination_type, destination_id, filters)
+	if err != nil {
+		return nil, err
+	}
+	for _, mapping := range mappings {
+		if mapping.Datastream == datastream_id {
+			d.Set(DESTINATION_TYPE, mapping.DestinationType)
+			d.Set(DESTINATION_ID, destination_id)
+			d.SetId(strconv.Itoa(mapping.ID))
+			return []*schema.ResourceData{d}, nil
+		}
+	}
+	return nil, fmt.Errorf("could not find a mapping of datastream %d to destination %d", datastream_id, destination_id)
+}
 
-	return []*schema.ResourceData{d}, nil
+func expandClusteringFields(d *schema.ResourceData) []string {
+	clusteringFields := []string{}
+	for _, field := range d.Get("clustering_fields").([]interface{}) {
+		clusteringFields = append(clusteringFields, field.(string))
+	}
+	return clusteringFields
 }
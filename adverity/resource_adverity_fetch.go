@@ -70,17 +70,88 @@ func fetch() *schema.Resource {
 			"job_id": {
 				Type:        schema.TypeInt,
 				Computed:    true,
-				Description: "The ID in Adverity for this fetching job.",
+				Description: "The ID in Adverity of the first job created by this fetch. Use job_ids when the datastream type splits a fetch into several jobs.",
+			},
+			"job_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+				Description: "The IDs in Adverity of all the jobs created by this fetch.",
+			},
+			"jobs": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the job.",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The API url of the job.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the job at the time this resource was last read.",
+						},
+						"progress": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The progress of the job, as a percentage.",
+						},
+						"finished": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the job has finished.",
+						},
+						"issues": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"message": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The message of the issue.",
+									},
+									"type": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The type of the issue.",
+									},
+									"extraction_stage": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The extraction stage in which the issue occurred.",
+									},
+									"url": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The url of the issue in Adverity.",
+									},
+								},
+							},
+							Description: "The issues reported for the job.",
+						},
+					},
+				},
+				Description: "The status, progress and issues of every job created by this fetch, in the same order as job_ids.",
 			},
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "The status of the job at the time this resource was last read.",
+				Description: "The status of the first job at the time this resource was last read.",
 			},
 			"finished": {
 				Type:        schema.TypeBool,
 				Computed:    true,
-				Description: "Whether the job has finished.",
+				Description: "Whether all jobs created by this fetch have finished.",
 			},
 			"is_waiting": {
 				Type:        schema.TypeBool,
@@ -101,53 +172,10 @@ func fetchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 		})
 		d.Set("is_waiting", true)
 	} else {
-		datastreamID := d.Get("datastream_id").(string)
-		mode := d.Get("mode").(string)
-		daysToFetch := d.Get("days_to_fetch").(int)
-		wait := d.Get("wait_until_completion").(bool)
-		start_date := d.Get("start_date").(string)
-		end_date := d.Get("end_date").(string)
-		providerConfig := m.(*config)
-		client := *providerConfig.Client
-		var err error
-		var response *adverityclient.FetchResponse
-		switch mode {
-		case "days":
-			response, err = client.FetchNumberOfDays(daysToFetch, datastreamID)
-		case "previous_months":
-			response, err = client.FetchPreviousMonths(daysToFetch, datastreamID)
-		case "current_month":
-			response, err = client.FetchCurrentMonth(datastreamID)
-		case "previous_weeks":
-			response, err = client.FetchPreviousWeeks(daysToFetch, datastreamID)
-		case "current_week":
-			response, err = client.FetchCurrentWeek(datastreamID)
-		case "custom":
-			response, err = client.FetchOnDate(start_date, end_date, datastreamID)
-		default:
-			err = errorString{fmt.Sprintf("%q is not implemented, should have been caught by schema validation.", mode)}
-		}
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		d.Set("job_id", response.Jobs[0].ID)
-		if wait {
-			complete := false
-			for !complete {
-				diagsFromRead := fetchRead(ctx, d, m)
-				if diagsFromRead.HasError() {
-					return append(diags, diagsFromRead...)
-				}
-				diags = append(diags, diagsFromRead...)
-				complete = d.Get("finished").(bool)
-				time.Sleep(10 * time.Second)
-			}
-		} else {
-			diagsFromRead := fetchRead(ctx, d, m)
-			if diagsFromRead.HasError() {
-				return append(diags, diagsFromRead...)
-			}
-			diags = append(diags, diagsFromRead...)
+		diagsFromFetch := fetchStart(ctx, d, m)
+		diags = append(diags, diagsFromFetch...)
+		if diagsFromFetch.HasError() {
+			return diags
 		}
 		d.Set("is_waiting", false)
 	}
@@ -163,20 +191,43 @@ func fetchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.
 	var diags diag.Diagnostics
 	disable := d.Get("disable").(bool)
 	if !disable {
-		jobID := d.Get("job_id").(int)
+		jobIDs := []int{}
+		for _, jobID := range d.Get("job_ids").([]interface{}) {
+			jobIDs = append(jobIDs, jobID.(int))
+		}
+		// State created before job_ids existed only holds the ID of the first job
+		if len(jobIDs) == 0 && d.Get("job_id").(int) != 0 {
+			jobIDs = append(jobIDs, d.Get("job_id").(int))
+			d.Set("job_ids", jobIDs)
+		}
 		providerConfig := m.(*config)
 		client := *providerConfig.Client
-		res, err, code := client.ReadJob(jobID)
-		if err != nil {
-			if code == 404 {
-				d.SetId("")
-				return diags
-			} else {
-				return diag.FromErr(err)
+		jobs := []adverityclient.Job{}
+		for _, jobID := range jobIDs {
+			res, err, code := client.ReadJob(jobID)
+			if err != nil {
+				if code == 404 {
+					d.SetId("")
+					return diags
+				} else {
+					return diag.FromErr(err)
+				}
+			}
+			jobs = append(jobs, *res)
+		}
+		if err := d.Set("jobs", flattenJobs(jobs)); err != nil {
+			return diag.FromErr(err)
+		}
+		finished := true
+		for _, job := range jobs {
+			if job.JobEnd == "" {
+				finished = false
 			}
 		}
-		d.Set("status", res.StateLabel)
-		d.Set("finished", res.JobEnd != "")
+		if len(jobs) > 0 {
+			d.Set("status", jobs[0].StateLabel)
+		}
+		d.Set("finished", finished)
 	}
 	return diags
 }
@@ -184,50 +235,10 @@ func fetchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.
 func fetchUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 	if d.Get("is_waiting").(bool) && !d.Get("disable").(bool) {
-		datastreamID := d.Get("datastream_id").(string)
-		mode := d.Get("mode").(string)
-		daysToFetch := d.Get("days_to_fetch").(int)
-		wait := d.Get("wait_until_completion").(bool)
-		start_date := d.Get("start_date").(string)
-		end_date := d.Get("end_date").(string)
-		providerConfig := m.(*config)
-		client := *providerConfig.Client
-		var err error
-		var response *adverityclient.FetchResponse
-		switch mode {
-		case "days":
-			response, err = client.FetchNumberOfDays(daysToFetch, datastreamID)
-		case "previous_months":
-			response, err = client.FetchPreviousMonths(daysToFetch, datastreamID)
-		case "current_month":
-			response, err = client.FetchCurrentMonth(datastreamID)
-		case "previous_weeks":
-			response, err = client.FetchPreviousWeeks(daysToFetch, datastreamID)
-		case "current_week":
-			response, err = client.FetchCurrentWeek(datastreamID)
-		case "custom":
-			response, err = client.FetchOnDate(start_date, end_date, datastreamID)
-		default:
-			err = errorString{fmt.Sprintf("%q is not implemented, should have been caught by schema validation.", mode)}
-		}
-		if err != nil {
-			return diag.FromErr(err)
-		}
-		d.Set("job_id", response.Jobs[0].ID)
-		if wait {
-			complete := false
-			for !complete {
-				diagsFromRead := fetchRead(ctx, d, m)
-				if diagsFromRead.HasError() {
-					return append(diags, diagsFromRead...)
-				}
-				diags = append(diags, diagsFromRead...)
-				complete = d.Get("finished").(bool)
-				time.Sleep(10 * time.Second)
-			}
-			time.Sleep(10 * time.Second)
-		} else {
-			diags = append(diags, fetchRead(ctx, d, m)...)
+		diagsFromFetch := fetchStart(ctx, d, m)
+		diags = append(diags, diagsFromFetch...)
+		if diagsFromFetch.HasError() {
+			return diags
 		}
 		d.Set("is_waiting", false)
 	} else {
@@ -243,3 +254,95 @@ func fetchDelete(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 	d.SetId("")
 	return nil
 }
+
+// fetchStart schedules the fetch in Adverity, stores all the jobs it returns and, if configured, waits until all of them have finished.
+func fetchStart(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	datastreamID := d.Get("datastream_id").(string)
+	mode := d.Get("mode").(string)
+	daysToFetch := d.Get("days_to_fetch").(int)
+	wait := d.Get("wait_until_completion").(bool)
+	start_date := d.Get("start_date").(string)
+	end_date := d.Get("end_date").(string)
+	providerConfig := m.(*config)
+	client := *providerConfig.Client
+	var err error
+	var response *adverityclient.FetchResponse
+	switch mode {
+	case "days":
+		response, err = client.FetchNumberOfDays(daysToFetch, datastreamID)
+	case "previous_months":
+		response, err = client.FetchPreviousMonths(daysToFetch, datastreamID)
+	case "current_month":
+		response, err = client.FetchCurrentMonth(datastreamID)
+	case "previous_weeks":
+		response, err = client.FetchPreviousWeeks(daysToFetch, datastreamID)
+	case "current_week":
+		response, err = client.FetchCurrentWeek(datastreamID)
+	case "custom":
+		response, err = client.FetchOnDate(start_date, end_date, datastreamID)
+	default:
+		err = errorString{fmt.Sprintf("%q is not implemented, should have been caught by schema validation.", mode)}
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(response.Jobs) == 0 {
+		return diag.Errorf("Adverity did not return any jobs for the fetch of datastream %s (status: %q, message: %q).", datastreamID, response.Status, response.Message)
+	}
+	jobIDs := []int{}
+	for _, job := range response.Jobs {
+		jobIDs = append(jobIDs, job.ID)
+	}
+	d.Set("job_id", jobIDs[0])
+	d.Set("job_ids", jobIDs)
+	if wait {
+		complete := false
+		for !complete {
+			diagsFromRead := fetchRead(ctx, d, m)
+			if diagsFromRead.HasError() {
+				return append(diags, diagsFromRead...)
+			}
+			diags = append(diags, diagsFromRead...)
+			complete = d.Get("finished").(bool)
+			if !complete {
+				time.Sleep(10 * time.Second)
+			}
+		}
+	} else {
+		diagsFromRead := fetchRead(ctx, d, m)
+		if diagsFromRead.HasError() {
+			return append(diags, diagsFromRead...)
+		}
+		diags = append(diags, diagsFromRead...)
+	}
+	return diags
+}
+
+func flattenJobs(jobs []adverityclient.Job) []interface{} {
+	flattened := make([]interface{}, len(jobs), len(jobs))
+	for i, job := range jobs {
+		jb := make(map[string]interface{})
+		jb["id"] = job.ID
+		jb["url"] = job.URL
+		jb["status"] = job.StateLabel
+		jb["progress"] = job.Progress
+		jb["finished"] = job.JobEnd != ""
+		jb["issues"] = flattenIssues(job.Issues)
+		flattened[i] = jb
+	}
+	return flattened
+}
+
+func flattenIssues(issues []adverityclient.Issue) []interface{} {
+	flattened := make([]interface{}, len(issues), len(issues))
+	for i, issue := range issues {
+		iss := make(map[string]interface{})
+		iss["message"] = issue.Message
+		iss["type"] = issue.TypeLabel
+		iss["extraction_stage"] = issue.ExtractionStageLabele
+		iss["url"] = issue.URL
+		flattened[i] = iss
+	}
+	return flattened
+}
@@ -2,13 +2,28 @@ package adverity
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/devoteamgcloud/adverityclient"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// storageSchemes maps the url schemes supported for storages to the block holding the settings of that type of storage.
+var storageSchemes = map[string]string{
+	"gs":    "gcs",
+	"s3":    "s3",
+	"azure": "azure",
+	"sftp":  "sftp",
+}
+
+// storageTypes are the blocks with storage-type-specific settings, at most one of which can be set.
+var storageTypes = []string{"gcs", "s3", "azure", "sftp"}
+
 func storage() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: storageCreate,
@@ -31,36 +46,122 @@ func storage() *schema.Resource {
 				Description: "The workspace ID this storage should be made in.",
 			},
 			"url": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The url of the externl storage location.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateStorageURL,
+				Description:  "The url of the external storage location. Supported schemes are gs:// for Google Cloud Storage, s3:// for Amazon S3, azure:// for Azure Blob Storage and sftp:// for SFTP servers. Other schemes give a warning.",
 			},
 			"auth": {
 				Type:        schema.TypeInt,
 				Required:    true,
 				Description: "The ID of the connection that authorises this storage.",
 			},
+			"backup_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If set to true, existing files are backed up before they are overwritten.",
+			},
+			"gcs": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"s3", "azure", "sftp"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						PROJECT_ID: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the Google Cloud project the bucket is billed to.",
+						},
+					},
+				},
+				Description: "Settings for a storage on Google Cloud Storage. Only allowed with a gs:// url.",
+			},
+			"s3": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"gcs", "azure", "sftp"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The AWS region of the bucket.",
+						},
+						"server_side_encryption": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"AES256", "aws:kms"}, false),
+							Description:  "The server side encryption used for the files. One of AES256 or aws:kms.",
+						},
+						"kms_key_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The ID of the KMS key used when server_side_encryption is aws:kms.",
+						},
+					},
+				},
+				Description: "Settings for a storage on Amazon S3. Only allowed with a s3:// url.",
+			},
+			"azure": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"gcs", "s3", "sftp"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"account_name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the Azure storage account.",
+						},
+						"access_tier": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"Hot", "Cool", "Archive"}, false),
+							Description:  "The access tier of the files. One of Hot, Cool or Archive.",
+						},
+					},
+				},
+				Description: "Settings for a storage on Azure Blob Storage. Only allowed with an azure:// url.",
+			},
+			"sftp": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"gcs", "s3", "azure"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      22,
+							ValidateFunc: validation.IsPortNumber,
+							Description:  "The port of the SFTP server. Defaults to 22.",
+						},
+						"host_key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The public host key of the SFTP server, used to verify the server.",
+						},
+					},
+				},
+				Description: "Settings for a storage on a SFTP server. Only allowed with a sftp:// url.",
+			},
 		},
-		Description: "A resource creating a storage needed for creating new workspaces.",
+		CustomizeDiff: validateStorageType,
+		Description:   "A resource creating a storage needed for creating new workspaces.",
 	}
 }
 
 func storageCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	name := d.Get("name").(string)
-	url := d.Get("url").(string)
-	stack := d.Get("stack").(int)
-	auth := d.Get("auth").(int)
-
 	providerConfig := m.(*config)
 
 	client := *providerConfig.Client
 
-	conf := adverityclient.StorageConfig{
-		Name:  name,
-		Stack: stack,
-		Auth:  auth,
-		URL:   url,
-	}
+	conf := expandStorageConfig(d)
 
 	res, err := client.CreateStorage(conf)
 
@@ -91,26 +192,20 @@ func storageRead(ctx context.Context, d *schema.ResourceData, m interface{}) dia
 	d.Set("stack", res.Stack)
 	d.Set("auth", res.Auth)
 	d.Set("url", res.URL)
+	d.Set("backup_existing", res.BackupExisting)
+	if err := setStorageType(d, res); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return diags
 }
 
 func storageUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	name := d.Get("name").(string)
-	url := d.Get("url").(string)
-	stack := d.Get("stack").(int)
-	auth := d.Get("auth").(int)
-
 	providerConfig := m.(*config)
 
 	client := *providerConfig.Client
 
-	conf := adverityclient.StorageConfig{
-		Name:  name,
-		Stack: stack,
-		Auth:  auth,
-		URL:   url,
-	}
+	conf := expandStorageConfig(d)
 
 	_, err := client.UpdateStorage(conf, d.Id())
 
@@ -136,10 +231,165 @@ func storageDelete(ctx context.Context, d *schema.ResourceData, m interface{}) d
 	return diags
 }
 
-// Useless function, included in case format changes in the future
+// storageImportHelper imports a storage by its ID. The storage type block is derived from the url on the next read.
 func storageImportHelper(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-
-	d.SetId(d.Id())
+	if _, err := strconv.Atoi(d.Id()); err != nil {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected the numeric storage_id", d.Id())
+	}
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// expandStorageConfig builds the storage config from the resource data, including the settings of the configured storage type.
+func expandStorageConfig(d *schema.ResourceData) adverityclient.StorageConfig {
+	conf := adverityclient.StorageConfig{
+		Name:           d.Get("name").(string),
+		Stack:          d.Get("stack").(int),
+		Auth:           d.Get("auth").(int),
+		URL:            d.Get("url").(string),
+		BackupExisting: d.Get("backup_existing").(bool),
+	}
+	if block, exists := d.GetOk("gcs.0"); exists {
+		settings := block.(map[string]interface{})
+		conf.ProjectID = settings[PROJECT_ID].(string)
+	}
+	if block, exists := d.GetOk("s3.0"); exists {
+		settings := block.(map[string]interface{})
+		conf.Region = settings["region"].(string)
+		conf.ServerSideEncryption = settings["server_side_encryption"].(string)
+		conf.KMSKeyID = settings["kms_key_id"].(string)
+	}
+	if block, exists := d.GetOk("azure.0"); exists {
+		settings := block.(map[string]interface{})
+		conf.AccountName = settings["account_name"].(string)
+		conf.AccessTier = settings["access_tier"].(string)
+	}
+	if block, exists := d.GetOk("sftp.0"); exists {
+		settings := block.(map[string]interface{})
+		conf.Port = settings["port"].(int)
+		conf.HostKey = settings["host_key"].(string)
+	}
+	return conf
+}
+
+// setStorageType reads the storage-type-specific settings back into the block matching the scheme of the url. The block is
+// only set when it is configured or when the API returns settings for it, so storages without any settings don't show drift.
+func setStorageType(d *schema.ResourceData, res *adverityclient.Storage) error {
+	storageType := ""
+	if u, err := url.Parse(res.URL); err == nil {
+		storageType = storageSchemes[strings.ToLower(u.Scheme)]
+	}
+	for _, name := range storageTypes {
+		if name != storageType {
+			if err := d.Set(name, nil); err != nil {
+				return err
+			}
+		}
+	}
+	_, configured := d.GetOk(storageType)
+	switch storageType {
+	case "gcs":
+		if configured || res.ProjectID != "" {
+			return d.Set(storageType, []interface{}{
+				map[string]interface{}{
+					PROJECT_ID: res.ProjectID,
+				},
+			})
+		}
+	case "s3":
+		if configured || res.Region != "" {
+			return d.Set(storageType, []interface{}{
+				map[string]interface{}{
+					"region":                 res.Region,
+					"server_side_encryption": res.ServerSideEncryption,
+					"kms_key_id":             res.KMSKeyID,
+				},
+			})
+		}
+	case "azure":
+		if configured || res.AccountName != "" {
+			return d.Set(storageType, []interface{}{
+				map[string]interface{}{
+					"account_name": res.AccountName,
+					"access_tier":  res.AccessTier,
+				},
+			})
+		}
+	case "sftp":
+		if configured || res.Port != 0 || res.HostKey != "" {
+			port := res.Port
+			if port == 0 {
+				port = 22
+			}
+			return d.Set(storageType, []interface{}{
+				map[string]interface{}{
+					"port":     port,
+					"host_key": res.HostKey,
+				},
+			})
+		}
+	}
+	return nil
+}
+
+// validateStorageURL checks that the url of a storage uses one of the supported schemes and names the bucket, container or
+// server to store the files in. Other schemes only give a warning, as the storage types of an instance can differ.
+func validateStorageURL(val interface{}, key string) (warns []string, errs []error) {
+	value := val.(string)
+	u, err := url.Parse(value)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%q is not a valid url: %v", key, err))
+		return warns, errs
+	}
+	if u.Scheme == "" {
+		errs = append(errs, fmt.Errorf("%q must start with one of gs://, s3://, azure:// or sftp://, got: %s", key, value))
+		return warns, errs
+	}
+	if _, exists := storageSchemes[strings.ToLower(u.Scheme)]; !exists {
+		warns = append(warns, fmt.Sprintf("%q uses the scheme %s://, which is not one of the supported gs://, s3://, azure:// or sftp://", key, u.Scheme))
+	}
+	if u.Host == "" {
+		switch strings.ToLower(u.Scheme) {
+		case "sftp":
+			errs = append(errs, fmt.Errorf("%q must contain the host of the SFTP server, got: %s", key, value))
+		case "azure":
+			errs = append(errs, fmt.Errorf("%q must contain the name of the container, got: %s", key, value))
+		case "gs", "s3":
+			errs = append(errs, fmt.Errorf("%q must contain the name of the bucket, got: %s", key, value))
+		default:
+			errs = append(errs, fmt.Errorf("%q must contain a host, got: %s", key, value))
+		}
+	}
+	return warns, errs
+}
+
+// validateStorageType is a CustomizeDiff function that makes sure the configured storage type block matches the scheme of the url.
+func validateStorageType(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("url") {
+		return nil
+	}
+	u, err := url.Parse(d.Get("url").(string))
+	if err != nil {
+		return nil
+	}
+	storageType := storageSchemes[strings.ToLower(u.Scheme)]
+	for _, name := range storageTypes {
+		if name == storageType {
+			continue
+		}
+		if blocks, ok := d.Get(name).([]interface{}); ok && len(blocks) > 0 {
+			return fmt.Errorf("the %s block can't be used with url %s, it only applies to %s:// urls", name, u.String(), storageSchemeOf(name))
+		}
+	}
+	return nil
+}
+
+// storageSchemeOf returns the url scheme of a storage type.
+func storageSchemeOf(storageType string) string {
+	for scheme, name := range storageSchemes {
+		if name == storageType {
+			return scheme
+		}
+	}
+	return ""
+}
@@ -2,13 +2,15 @@ package adverity
 
 import (
 	"context"
+	"fmt"
+	"log"
+	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/devoteamgcloud/adverityclient"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	// 	"log"
 )
 
 func workspace() *schema.Resource {
@@ -38,10 +40,46 @@ func workspace() *schema.Resource {
 				Default:     1,
 				Description: "The ID of the parent workspace.",
 			},
+			"force_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If set to true, destroying the workspace also deletes its child workspaces, datastreams and connections. Otherwise the workspace can only be destroyed once it is empty.",
+			},
+			"default_manage_extract_names": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "The default of manage_extract_names for new datastreams in this workspace.",
+			},
+			"permissions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"is_creator": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the user of the provider is a creator in this workspace.",
+						},
+						"is_datastream_manager": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the user of the provider is a datastream manager in this workspace.",
+						},
+						"is_viewer": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the user of the provider is a viewer in this workspace.",
+						},
+					},
+				},
+				Description: "The permissions the user of the provider has in this workspace. Use adverity_workspace_member to give others access.",
+			},
 			SLUG: {
 				Type:        schema.TypeString,
 				Computed:    true,
-				Description: "The slug of this workspace.",
+				Description: "The slug of this workspace. The slug changes when the workspace is renamed, the ID does not.",
 			},
 		},
 		Description: "Theis resource will create a new workspace.",
@@ -62,6 +100,11 @@ func workspaceCreate(ctx context.Context, d *schema.ResourceData, m interface{})
 		DatalakeID: datalakeId,
 		ParentID:   parentId,
 	}
+	// GetOkExists is deprecated, but GetOk can't tell a default set to false apart from a default that isn't set
+	if defaultManageExtractNames, exists := d.GetOkExists("default_manage_extract_names"); exists {
+		manageExtractNames := defaultManageExtractNames.(bool)
+		conf.DefaultManageExtractNames = &manageExtractNames
+	}
 
 	res, err := client.CreateWorkspace(conf)
 
@@ -69,7 +112,7 @@ func workspaceCreate(ctx context.Context, d *schema.ResourceData, m interface{})
 		return diag.FromErr(err)
 	}
 
-	d.Set(SLUG, res.Slug)
+	d.SetId(strconv.Itoa(res.ID))
 
 	return workspaceRead(ctx, d, m)
 }
@@ -77,13 +120,11 @@ func workspaceCreate(ctx context.Context, d *schema.ResourceData, m interface{})
 func workspaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
-	slug := d.Get(SLUG).(string)
-
 	providerConfig := m.(*config)
 
 	client := *providerConfig.Client
 
-	res, err, code := client.ReadWorkspace(slug)
+	res, err, code := client.ReadWorkspace(d.Id())
 	if err != nil {
 		if code == 404 {
 			d.SetId("")
@@ -91,12 +132,22 @@ func workspaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) d
 		}
 		return diag.FromErr(err)
 	}
-	d.SetId(strconv.Itoa(res.ID))
 
-	cutDatalake := strings.SplitN(res.Datalake, "/", -1)
-	datalakeId := cutDatalake[len(cutDatalake)-2]
+	datalakeId, err := datalakeIDFromURL(res.Datalake)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	d.Set(DATALAKE_ID, datalakeId)
+	d.Set(SLUG, res.Slug)
+	d.Set("default_manage_extract_names", res.DefaultManageExtractNames)
+	d.Set("permissions", []interface{}{
+		map[string]interface{}{
+			"is_creator":            res.Permissions.IsCreator,
+			"is_datastream_manager": res.Permissions.IsDatastreamManager,
+			"is_viewer":             res.Permissions.IsViewer,
+		},
+	})
 	d.Set(PARENT_ID, res.ParentID)
 	d.Set(NAME, res.Name)
 
@@ -106,7 +157,6 @@ func workspaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) d
 func workspaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	parentId := d.Get(PARENT_ID).(int)
 	name := d.Get(NAME).(string)
-	slug := d.Get(SLUG).(string)
 	datalake_id := d.Get("datalake_id").(string)
 
 	providerConfig := m.(*config)
@@ -115,12 +165,15 @@ func workspaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{})
 
 	conf := adverityclient.UpdateWorkspaceConfig{
 		Name:       name,
-		StackSlug:  slug,
 		ParentID:   parentId,
 		DatalakeID: datalake_id,
 	}
+	if defaultManageExtractNames, exists := d.GetOkExists("default_manage_extract_names"); exists {
+		manageExtractNames := defaultManageExtractNames.(bool)
+		conf.DefaultManageExtractNames = &manageExtractNames
+	}
 
-	_, err := client.UpdateWorkspace(conf)
+	_, err := client.UpdateWorkspace(conf, d.Id())
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -133,21 +186,174 @@ func workspaceDelete(ctx context.Context, d *schema.ResourceData, m interface{})
 	providerConfig := m.(*config)
 
 	client := *providerConfig.Client
-	conf := adverityclient.DeleteWorkspaceConfig{
-		StackSlug: d.Get(SLUG).(string),
-	}
-
-	_, err := client.DeleteWorkspace(conf)
 
+	res, err, code := client.ReadWorkspace(d.Id())
 	if err != nil {
+		if code == 404 {
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+	workspaces, err := client.ListWorkspaces()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	contents, err := listWorkspaceContents(client, res.ID, workspaces)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !contents.empty() && !d.Get("force_destroy").(bool) {
+		return diag.Errorf("Workspace %s is not empty, it still contains %s. Remove these first, or set force_destroy to true to delete them together with the workspace.", res.Name, contents.describe())
+	}
+	if err := deleteWorkspaceRecursively(client, *res, workspaces); err != nil {
 		return diag.FromErr(err)
 	}
 
 	return diags
 }
 
+// workspaceContents are the children of a workspace that have to be deleted before the workspace itself.
+type workspaceContents struct {
+	workspaces  []adverityclient.Workspace
+	datastreams []adverityclient.Datastream
+	connections []adverityclient.Connection
+}
+
+func (c workspaceContents) empty() bool {
+	return len(c.workspaces) == 0 && len(c.datastreams) == 0 && len(c.connections) == 0
+}
+
+func (c workspaceContents) describe() string {
+	parts := []string{}
+	if len(c.workspaces) > 0 {
+		names := []string{}
+		for _, workspace := range c.workspaces {
+			names = append(names, workspace.Name)
+		}
+		parts = append(parts, fmt.Sprintf("%d workspace(s): %s", len(names), strings.Join(names, ", ")))
+	}
+	if len(c.datastreams) > 0 {
+		names := []string{}
+		for _, datastream := range c.datastreams {
+			names = append(names, datastream.Name)
+		}
+		parts = append(parts, fmt.Sprintf("%d datastream(s): %s", len(names), strings.Join(names, ", ")))
+	}
+	if len(c.connections) > 0 {
+		names := []string{}
+		for _, connection := range c.connections {
+			names = append(names, connection.Name)
+		}
+		parts = append(parts, fmt.Sprintf("%d connection(s): %s", len(names), strings.Join(names, ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// listWorkspaceContents lists the child workspaces, datastreams and connections of a workspace. Datastreams and connections
+// are only listed if the counts of the workspace say there are any.
+func listWorkspaceContents(client adverityclient.Client, workspaceId int, workspaces []adverityclient.Workspace) (workspaceContents, error) {
+	contents := workspaceContents{}
+	var workspace adverityclient.Workspace
+	for _, ws := range workspaces {
+		if ws.ParentID == workspaceId && ws.ID != workspaceId {
+			contents.workspaces = append(contents.workspaces, ws)
+		}
+		if ws.ID == workspaceId {
+			workspace = ws
+		}
+	}
+	filters := []adverityclient.Query{
+		{
+			Key:   "stack_id",
+			Value: strconv.Itoa(workspaceId),
+		},
+	}
+	if workspace.Counts.Datastreams > 0 {
+		datastreams, err := client.ListDatastreams(filters)
+		if err != nil {
+			return contents, err
+		}
+		for _, datastream := range datastreams {
+			if datastream.StackID == workspaceId {
+				contents.datastreams = append(contents.datastreams, datastream)
+			}
+		}
+	}
+	if workspace.Counts.Connections > 0 {
+		connections, err := client.ListConnections(filters)
+		if err != nil {
+			return contents, err
+		}
+		for _, connection := range connections {
+			if connection.Stack == workspaceId {
+				contents.connections = append(contents.connections, connection)
+			}
+		}
+	}
+	return contents, nil
+}
+
+// deleteWorkspaceRecursively deletes a workspace in dependency order: first its child workspaces, then its datastreams, which
+// depend on connections, then its connections and finally the workspace itself.
+func deleteWorkspaceRecursively(client adverityclient.Client, workspace adverityclient.Workspace, workspaces []adverityclient.Workspace) error {
+	contents, err := listWorkspaceContents(client, workspace.ID, workspaces)
+	if err != nil {
+		return err
+	}
+	for _, child := range contents.workspaces {
+		if err := deleteWorkspaceRecursively(client, child, workspaces); err != nil {
+			return err
+		}
+	}
+	for _, datastream := range contents.datastreams {
+		log.Printf("[DEBUG] Deleting datastream %s of workspace %s", datastream.Name, workspace.Name)
+		if _, err := client.DeleteDatastream(strconv.Itoa(datastream.ID), datastream.DatastreamTypeID); err != nil {
+			return err
+		}
+	}
+	for _, connection := range contents.connections {
+		log.Printf("[DEBUG] Deleting connection %s of workspace %s", connection.Name, workspace.Name)
+		if _, err := client.DeleteConnectionByID(strconv.Itoa(connection.ID)); err != nil {
+			return err
+		}
+	}
+	_, err = client.DeleteWorkspace(strconv.Itoa(workspace.ID))
+	return err
+}
+
+// workspaceImportHelper accepts either the ID or the slug of a workspace. Slugs are looked up, since the state is keyed by ID.
 func workspaceImportHelper(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	d.Set(SLUG, d.Id())
+	d.Set("force_destroy", false)
+	if _, err := strconv.Atoi(d.Id()); err == nil {
+		return []*schema.ResourceData{d}, nil
+	}
+
+	providerConfig := m.(*config)
+
+	client := *providerConfig.Client
+
+	res, err, _ := client.ReadWorkspace(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(strconv.Itoa(res.ID))
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// datalakeIDFromURL returns the ID of a datalake from its API url, e.g. https://example.datatap.adverity.com/api/datalakes/3/.
+func datalakeIDFromURL(datalakeURL string) (string, error) {
+	if datalakeURL == "" {
+		return "", nil
+	}
+	parsed, err := url.Parse(datalakeURL)
+	if err != nil {
+		return "", fmt.Errorf("could not parse datalake url %q: %s", datalakeURL, err)
+	}
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	datalakeId := segments[len(segments)-1]
+	if _, err := strconv.Atoi(datalakeId); err != nil {
+		return "", fmt.Errorf("could not find the datalake ID in datalake url %q, expected it to end in /datalakes/{datalake_id}/", datalakeURL)
+	}
+	return datalakeId, nil
+}
@@ -0,0 +1,159 @@
+package adverity
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/devoteamgcloud/adverityclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func workspaceMember() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: workspaceMemberCreate,
+		ReadContext:   workspaceMemberRead,
+		UpdateContext: workspaceMemberUpdate,
+		DeleteContext: workspaceMemberDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: workspaceMemberImportHelper,
+		},
+
+		Schema: map[string]*schema.Schema{
+			WORKSPACE_ID: {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the workspace to give access to.",
+			},
+			"user_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"user_id", "group_id"},
+				Description:  "The ID of the user to give access to the workspace.",
+			},
+			"group_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"user_id", "group_id"},
+				Description:  "The ID of the group to give access to the workspace.",
+			},
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"creator", "datastream_manager", "viewer"}, false),
+				Description:  "The role of the user or group in the workspace. One of creator, datastream_manager or viewer.",
+			},
+		},
+		Description: "This resource gives a user or a group access to a workspace with the given role.",
+	}
+}
+
+func workspaceMemberCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	workspaceId := d.Get(WORKSPACE_ID).(int)
+
+	providerConfig := m.(*config)
+
+	client := *providerConfig.Client
+
+	conf := adverityclient.WorkspaceMemberConfig{
+		User:  d.Get("user_id").(int),
+		Group: d.Get("group_id").(int),
+		Role:  d.Get("role").(string),
+	}
+
+	res, err := client.CreateWorkspaceMember(conf, strconv.Itoa(workspaceId))
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.Itoa(res.ID))
+
+	return workspaceMemberRead(ctx, d, m)
+}
+
+func workspaceMemberRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	workspaceId := d.Get(WORKSPACE_ID).(int)
+
+	providerConfig := m.(*config)
+
+	client := *providerConfig.Client
+
+	res, err, code := client.ReadWorkspaceMember(strconv.Itoa(workspaceId), d.Id())
+	if err != nil {
+		if code == 404 {
+			d.SetId("")
+			return diags
+		}
+		return diag.FromErr(err)
+	}
+	if res.User != 0 {
+		d.Set("user_id", res.User)
+	}
+	if res.Group != 0 {
+		d.Set("group_id", res.Group)
+	}
+	d.Set("role", res.Role)
+
+	return diags
+}
+
+func workspaceMemberUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	workspaceId := d.Get(WORKSPACE_ID).(int)
+
+	providerConfig := m.(*config)
+
+	client := *providerConfig.Client
+
+	conf := adverityclient.WorkspaceMemberConfig{
+		User:  d.Get("user_id").(int),
+		Group: d.Get("group_id").(int),
+		Role:  d.Get("role").(string),
+	}
+
+	_, err := client.UpdateWorkspaceMember(conf, strconv.Itoa(workspaceId), d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return workspaceMemberRead(ctx, d, m)
+}
+
+func workspaceMemberDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	workspaceId := d.Get(WORKSPACE_ID).(int)
+
+	providerConfig := m.(*config)
+
+	client := *providerConfig.Client
+
+	_, err := client.DeleteWorkspaceMember(strconv.Itoa(workspaceId), d.Id())
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func workspaceMemberImportHelper(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected workspace_id:member_id", d.Id())
+	}
+	workspace_id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("could not convert workspace_id (%s) to an integer", parts[0])
+	}
+	d.Set(WORKSPACE_ID, workspace_id)
+	d.SetId(parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
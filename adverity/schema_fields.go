@@ -0,0 +1,191 @@
+package adverity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/devoteamgcloud/adverityclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+const (
+	// nestedFieldsJSON stores a RECORD column as a single Adverity JSON column
+	nestedFieldsJSON = "json"
+	// nestedFieldsFlatten creates a column for every field of a RECORD column, named parent.field
+	nestedFieldsFlatten = "flatten"
+)
+
+func isRecordType(elementType string) bool {
+	upperType := strings.ToUpper(elementType)
+	return upperType == "RECORD" || upperType == "STRUCT"
+}
+
+// expandSchemaElements turns a (possibly nested) BigQuery schema into the flat list of columns that exist in Adverity.
+// REPEATED columns always become JSON columns, since a list of values can't be flattened into a single row.
+func expandSchemaElements(elements []adverityclient.SchemaElementMode, strategy string, separator string) ([]columnDefinition, error) {
+	return expandSchemaElementsWithPrefix(elements, strategy, separator, "", false)
+}
+
+func expandSchemaElementsWithPrefix(elements []adverityclient.SchemaElementMode, strategy string, separator string, prefix string, parentNullable bool) ([]columnDefinition, error) {
+	definitions := []columnDefinition{}
+	for _, element := range elements {
+		name := prefix + element.Name
+		mode := strings.ToUpper(element.Mode)
+		if mode != "" && mode != "NULLABLE" && mode != "REQUIRED" && mode != "REPEATED" {
+			return nil, fmt.Errorf("column %q has mode %q, expected one of: NULLABLE, REQUIRED, REPEATED", name, element.Mode)
+		}
+		if isRecordType(element.Type) && len(element.Fields) == 0 {
+			return nil, fmt.Errorf("column %q is of type %s, but has no fields", name, element.Type)
+		}
+		if !isRecordType(element.Type) && len(element.Fields) > 0 {
+			return nil, fmt.Errorf("column %q has fields, but is of type %s instead of RECORD", name, element.Type)
+		}
+		// A field of a record that can be null can also be null once flattened, so it is relaxed to NULLABLE
+		relaxed := mode == "REQUIRED" && parentNullable
+		if relaxed {
+			mode = "NULLABLE"
+		}
+		if mode == "REPEATED" || (isRecordType(element.Type) && strategy != nestedFieldsFlatten) {
+			definitions = append(definitions, columnDefinition{
+				Name:           name,
+				Type:           element.Type,
+				Mode:           mode,
+				AdverityType:   "JSON",
+				RequiredFields: requiredFieldNames(element.Fields, name+"."),
+				RelaxedMode:    relaxed,
+			})
+			continue
+		}
+		if isRecordType(element.Type) {
+			fields, err := expandSchemaElementsWithPrefix(element.Fields, strategy, separator, name+separator, parentNullable || mode != "REQUIRED")
+			if err != nil {
+				return nil, err
+			}
+			definitions = append(definitions, fields...)
+			continue
+		}
+		definitions = append(definitions, columnDefinition{
+			Name:        name,
+			Type:        element.Type,
+			Mode:        mode,
+			RelaxedMode: relaxed,
+		})
+	}
+	return definitions, nil
+}
+
+// relaxedModeWarnings returns a warning listing the REQUIRED fields that were relaxed to NULLABLE when flattened, if there are any.
+func relaxedModeWarnings(definitions []columnDefinition) diag.Diagnostics {
+	var diags diag.Diagnostics
+	relaxed := []string{}
+	for _, definition := range definitions {
+		if definition.RelaxedMode {
+			relaxed = append(relaxed, definition.Name)
+		}
+	}
+	if len(relaxed) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "REQUIRED fields of records that are not REQUIRED are treated as NULLABLE",
+			Detail:   fmt.Sprintf("These fields become nullable when flattened, because their parent record can be null: %s", strings.Join(relaxed, ", ")),
+		})
+	}
+	return diags
+}
+
+// readSchemaElements rebuilds the defined (possibly nested) schema from the columns read from Adverity, keeping the modes and
+// fields of the defined schema so they don't show up as drift. Defined columns that don't exist in Adverity are left out,
+// unless they are ignored, and columns that only exist in Adverity are added at the end.
+func readSchemaElements(elements []adverityclient.SchemaElementMode, columns []adverityclient.Column, strategy string, separator string, dialect *typeDialect, ignoredColumns []string) []adverityclient.SchemaElementMode {
+	columnsByName := map[string]adverityclient.Column{}
+	for _, column := range columns {
+		columnsByName[column.Name] = column
+	}
+	ignored := map[string]bool{}
+	for _, column := range ignoredColumns {
+		ignored[column] = true
+	}
+	consumed := map[string]bool{}
+	readElements := readSchemaElementsWithPrefix(elements, columnsByName, consumed, strategy, separator, "", dialect, ignored)
+	for _, column := range columns {
+		if !consumed[column.Name] {
+			readElements = append(readElements, adverityclient.SchemaElementMode{
+				Name:   column.Name,
+				Type:   dialect.fromAdverityType(column.DataType, ""),
+				Mapped: column.TargetColumn != nil,
+			})
+		}
+	}
+	return readElements
+}
+
+func readSchemaElementsWithPrefix(elements []adverityclient.SchemaElementMode, columns map[string]adverityclient.Column, consumed map[string]bool, strategy string, separator string, prefix string, dialect *typeDialect, ignored map[string]bool) []adverityclient.SchemaElementMode {
+	readElements := []adverityclient.SchemaElementMode{}
+	for _, element := range elements {
+		name := prefix + element.Name
+		if isRecordType(element.Type) && strings.ToUpper(element.Mode) != "REPEATED" && strategy == nestedFieldsFlatten {
+			fields := readSchemaElementsWithPrefix(element.Fields, columns, consumed, strategy, separator, name+separator, dialect, ignored)
+			if len(fields) > 0 {
+				readElement := element
+				readElement.Fields = fields
+				readElements = append(readElements, readElement)
+			}
+			continue
+		}
+		column, found := columns[name]
+		if !found || consumed[name] {
+			if ignored[name] {
+				readElements = append(readElements, element)
+			}
+			continue
+		}
+		consumed[name] = true
+		readElement := element
+		readElement.Mapped = column.TargetColumn != nil
+		if isRecordType(element.Type) || strings.ToUpper(element.Mode) == "REPEATED" {
+			// Stored as a JSON column, so the defined type and fields can only be kept if the column is still JSON
+			if column.DataType != "JSON" {
+				readElement.Type = dialect.fromAdverityType(column.DataType, "")
+				readElement.Fields = nil
+			}
+		} else {
+			readElement.Type = dialect.fromAdverityType(column.DataType, element.Type)
+		}
+		readElements = append(readElements, readElement)
+	}
+	return readElements
+}
+
+// requiredFieldNames returns the names of all REQUIRED fields nested in the given fields, at any depth.
+func requiredFieldNames(fields []adverityclient.SchemaElementMode, prefix string) []string {
+	names := []string{}
+	for _, field := range fields {
+		name := prefix + field.Name
+		if strings.ToUpper(field.Mode) == "REQUIRED" {
+			names = append(names, name)
+		}
+		names = append(names, requiredFieldNames(field.Fields, name+".")...)
+	}
+	return names
+}
+
+// validateRequiredColumns makes sure columns with mode REQUIRED, or columns stored as JSON that contain REQUIRED fields, are not
+// left out of the columns in Adverity. Flattened fields are checked as columns of their own.
+func validateRequiredColumns(definitions []columnDefinition, setting string, columns []string) error {
+	excluded := map[string]bool{}
+	for _, column := range columns {
+		excluded[column] = true
+	}
+	for _, definition := range definitions {
+		if !excluded[definition.Name] {
+			continue
+		}
+		if definition.Mode == "REQUIRED" {
+			return fmt.Errorf("column %q has mode REQUIRED, so it can't be in %s", definition.Name, setting)
+		}
+		if len(definition.RequiredFields) > 0 {
+			return fmt.Errorf("column %q contains the REQUIRED field(s) %s, so it can't be in %s", definition.Name, strings.Join(definition.RequiredFields, ", "), setting)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,277 @@
+package adverity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/devoteamgcloud/adverityclient"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// typeDialect maps the datatypes of a warehouse to the datatypes Adverity uses for columns, and back.
+type typeDialect struct {
+	// toAdverity maps every accepted warehouse datatype (in upper case, without parameters) to an Adverity datatype
+	toAdverity map[string]string
+	// fromAdverity maps every Adverity datatype to the warehouse datatype used when reading columns back
+	fromAdverity map[string]string
+}
+
+// typeDialects is the registry of all supported warehouse type dialects. Adding a warehouse only requires adding an entry here.
+var typeDialects = map[string]*typeDialect{
+	"bigquery": {
+		toAdverity: map[string]string{
+			"STRING":     "String",
+			"BYTES":      "String",
+			"INTEGER":    "Long",
+			"INT64":      "Long",
+			"FLOAT":      "Float",
+			"FLOAT64":    "Float",
+			"NUMERIC":    "Float",
+			"BIGNUMERIC": "Float",
+			"DATE":       "Date",
+			"DATETIME":   "DateTime",
+			"TIMESTAMP":  "DateTime",
+			"BOOLEAN":    "Boolean",
+			"BOOL":       "Boolean",
+			"JSON":       "JSON",
+		},
+		fromAdverity: map[string]string{
+			"String":   "STRING",
+			"Long":     "INTEGER",
+			"Float":    "FLOAT",
+			"Date":     "DATE",
+			"DateTime": "DATETIME",
+			"Boolean":  "BOOLEAN",
+			"JSON":     "JSON",
+		},
+	},
+	"snowflake": {
+		toAdverity: map[string]string{
+			"VARCHAR":       "String",
+			"STRING":        "String",
+			"TEXT":          "String",
+			"CHAR":          "String",
+			"INT":           "Long",
+			"INTEGER":       "Long",
+			"BIGINT":        "Long",
+			"SMALLINT":      "Long",
+			"NUMBER":        "Float",
+			"NUMERIC":       "Float",
+			"DECIMAL":       "Float",
+			"FLOAT":         "Float",
+			"DOUBLE":        "Float",
+			"REAL":          "Float",
+			"DATE":          "Date",
+			"DATETIME":      "DateTime",
+			"TIMESTAMP":     "DateTime",
+			"TIMESTAMP_NTZ": "DateTime",
+			"TIMESTAMP_LTZ": "DateTime",
+			"TIMESTAMP_TZ":  "DateTime",
+			"BOOLEAN":       "Boolean",
+			"VARIANT":       "JSON",
+			"OBJECT":        "JSON",
+			"ARRAY":         "JSON",
+		},
+		fromAdverity: map[string]string{
+			"String":   "VARCHAR",
+			"Long":     "INTEGER",
+			"Float":    "FLOAT",
+			"Date":     "DATE",
+			"DateTime": "TIMESTAMP_NTZ",
+			"Boolean":  "BOOLEAN",
+			"JSON":     "VARIANT",
+		},
+	},
+	"redshift": {
+		toAdverity: map[string]string{
+			"VARCHAR":           "String",
+			"CHARACTER VARYING": "String",
+			"CHAR":              "String",
+			"CHARACTER":         "String",
+			"BPCHAR":            "String",
+			"TEXT":              "String",
+			"SMALLINT":          "Long",
+			"INTEGER":           "Long",
+			"BIGINT":            "Long",
+			"INT2":              "Long",
+			"INT4":              "Long",
+			"INT8":              "Long",
+			"REAL":              "Float",
+			"FLOAT4":            "Float",
+			"FLOAT8":            "Float",
+			"FLOAT":             "Float",
+			"DOUBLE PRECISION":  "Float",
+			"DECIMAL":           "Float",
+			"NUMERIC":           "Float",
+			"DATE":              "Date",
+			"TIMESTAMP":         "DateTime",
+			"TIMESTAMPTZ":       "DateTime",
+			"BOOLEAN":           "Boolean",
+			"BOOL":              "Boolean",
+			"SUPER":             "JSON",
+		},
+		fromAdverity: map[string]string{
+			"String":   "VARCHAR",
+			"Long":     "BIGINT",
+			"Float":    "DOUBLE PRECISION",
+			"Date":     "DATE",
+			"DateTime": "TIMESTAMP",
+			"Boolean":  "BOOLEAN",
+			"JSON":     "SUPER",
+		},
+	},
+	"postgres": {
+		toAdverity: map[string]string{
+			"TEXT":                        "String",
+			"VARCHAR":                     "String",
+			"CHARACTER VARYING":           "String",
+			"CHAR":                        "String",
+			"CHARACTER":                   "String",
+			"UUID":                        "String",
+			"SMALLINT":                    "Long",
+			"INTEGER":                     "Long",
+			"INT":                         "Long",
+			"BIGINT":                      "Long",
+			"SERIAL":                      "Long",
+			"BIGSERIAL":                   "Long",
+			"REAL":                        "Float",
+			"DOUBLE PRECISION":            "Float",
+			"NUMERIC":                     "Float",
+			"DECIMAL":                     "Float",
+			"DATE":                        "Date",
+			"TIMESTAMP":                   "DateTime",
+			"TIMESTAMPTZ":                 "DateTime",
+			"TIMESTAMP WITHOUT TIME ZONE": "DateTime",
+			"TIMESTAMP WITH TIME ZONE":    "DateTime",
+			"BOOLEAN":                     "Boolean",
+			"BOOL":                        "Boolean",
+			"JSON":                        "JSON",
+			"JSONB":                       "JSON",
+		},
+		fromAdverity: map[string]string{
+			"String":   "TEXT",
+			"Long":     "BIGINT",
+			"Float":    "DOUBLE PRECISION",
+			"Date":     "DATE",
+			"DateTime": "TIMESTAMP",
+			"Boolean":  "BOOLEAN",
+			"JSON":     "JSONB",
+		},
+	},
+}
+
+// typeDialectNames returns the names of all registered type dialects, sorted alphabetically.
+func typeDialectNames() []string {
+	names := []string{}
+	for name := range typeDialects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lookupTypeDialect(name string) (*typeDialect, error) {
+	dialect, exists := typeDialects[name]
+	if !exists {
+		return nil, fmt.Errorf("unknown type dialect %q, expected one of: %s", name, strings.Join(typeDialectNames(), ", "))
+	}
+	return dialect, nil
+}
+
+// normaliseWarehouseType upper cases a warehouse datatype and strips its parameters, so VARCHAR(255) becomes VARCHAR.
+func normaliseWarehouseType(warehouseType string) string {
+	if idx := strings.Index(warehouseType, "("); idx >= 0 {
+		warehouseType = warehouseType[:idx]
+	}
+	return strings.ToUpper(strings.TrimSpace(warehouseType))
+}
+
+// toAdverityType returns the Adverity datatype for a warehouse datatype, or an error if the datatype can't be mapped.
+func (t *typeDialect) toAdverityType(warehouseType string) (string, error) {
+	adverityType, exists := t.toAdverity[normaliseWarehouseType(warehouseType)]
+	if !exists {
+		accepted := []string{}
+		for acceptedType := range t.toAdverity {
+			accepted = append(accepted, acceptedType)
+		}
+		sort.Strings(accepted)
+		return "", fmt.Errorf("datatype %q has no mapping to an Adverity datatype, expected one of: %s", warehouseType, strings.Join(accepted, ", "))
+	}
+	return adverityType, nil
+}
+
+// fromAdverityType returns the warehouse datatype for an Adverity datatype read from the API. If the defined datatype maps to
+// the same Adverity datatype it is kept as is, so aliases such as NUMERIC or TIMESTAMP don't cause drift.
+func (t *typeDialect) fromAdverityType(adverityType string, definedType string) string {
+	if definedType != "" {
+		if mapped, err := t.toAdverityType(definedType); err == nil && mapped == adverityType {
+			return definedType
+		}
+	}
+	if warehouseType, exists := t.fromAdverity[adverityType]; exists {
+		return warehouseType
+	}
+	return adverityType
+}
+
+// validateColumnTypes is a CustomizeDiff function that makes sure every column type in the schema or the column blocks can be
+// mapped with the chosen type dialect, so unmapped types fail at plan time instead of being sent as empty datatypes.
+func validateColumnTypes(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("type_dialect") || !d.NewValueKnown("schema") || !d.NewValueKnown("column") {
+		return nil
+	}
+	dialect, err := lookupTypeDialect(d.Get("type_dialect").(string))
+	if err != nil {
+		return err
+	}
+	columnTypes := map[string]string{}
+	definitions := []columnDefinition{}
+	if schemaText := d.Get("schema").(string); schemaText != "" {
+		var definedSchema []adverityclient.SchemaElementMode
+		if err := json.Unmarshal([]byte(schemaText), &definedSchema); err != nil {
+			return err
+		}
+		// The separator doesn't matter here, only the types and modes of the columns are validated
+		definitions, err = expandSchemaElements(definedSchema, d.Get("nested_fields_strategy").(string), ".")
+		if err != nil {
+			return err
+		}
+		for _, column := range definitions {
+			// Nested columns are always stored as JSON, so their type doesn't need a mapping
+			if column.AdverityType == "" {
+				columnTypes[column.Name] = column.Type
+			}
+		}
+	} else {
+		for _, column := range d.Get("column").(*schema.Set).List() {
+			c := column.(map[string]interface{})
+			columnTypes[c["name"].(string)] = c["type"].(string)
+		}
+	}
+	ignoredColumns := map[string]bool{}
+	ignored := []string{}
+	for _, column := range d.Get("ignored_columns").([]interface{}) {
+		ignoredColumns[column.(string)] = true
+		ignored = append(ignored, column.(string))
+	}
+	if err := validateRequiredColumns(definitions, "ignored_columns", ignored); err != nil {
+		return err
+	}
+	errs := []string{}
+	for name, columnType := range columnTypes {
+		if ignoredColumns[name] {
+			continue
+		}
+		if _, err := dialect.toAdverityType(columnType); err != nil {
+			errs = append(errs, fmt.Sprintf("column %q: %s", name, err))
+		}
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("unmapped column types for type dialect %q:\n%s", d.Get("type_dialect").(string), strings.Join(errs, "\n"))
+	}
+	return nil
+}